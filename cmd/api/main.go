@@ -2,30 +2,148 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/Mhbib34/missing-person-service/cmd/wire"
-	"github.com/joho/godotenv"
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-func init() {
-	// Load .env hanya sekali di awal
-	err := godotenv.Load()
+func main() {
+	// log sebelum config terbaca tetap JSON
+	slog.SetDefault(logger.New(config.LogConfig{Level: "info"}))
+
+	// api = HTTP saja, worker = job saja, all = keduanya dalam satu proses
+	roleFlag := flag.String("role", "all", "process role: api, worker or all")
+	flag.Parse()
+
+	// `migrate ...` menjalankan migrasi lalu keluar
+	if flag.Arg(0) == "migrate" {
+		runMigrate(flag.Args()[1:])
+		return
+	}
+
+	role, err := wire.ParseRole(*roleFlag)
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		fatal("invalid role", err)
 	}
-}
+	runAPI := role.ServesHTTP()
+	runWorker := role.RunsJobs()
 
+	// config dibaca dari environment, .env dan config.yaml (opsional)
+	app, err := wire.InitializeRole(role)
+	if err != nil {
+		fatal("initialize server failed", err)
+	}
+	slog.SetDefault(app.Logger)
+	cfg := app.Config
 
-func main() {
-	app, err := wire.InitializeServer()
+	// jangan melayani request dengan schema yang tertinggal
+	if err := app.Migrator.Check(context.Background()); err != nil {
+		fatal("schema check failed", err)
+	}
+
+	prometheus.MustRegister(app.QueueMetrics)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
 	if err != nil {
-		log.Fatal(err)
+		fatal("setup tracing failed", err)
+	}
+
+	// SIGINT / SIGTERM memulai shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Worker punya context sendiri supaya tetap jalan selama HTTP di-drain
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		if !runWorker {
+			return
+		}
+		// job baru dibangunkan lewat NOTIFY, poll hanya cadangan
+		app.Worker.Start(workerCtx, cfg.Worker.PollInterval)
+	}()
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler: app.Router,
+	}
+
+	// tanpa API, /metrics dilayani di port sendiri
+	if !runAPI {
+		server = metrics.NewServer(cfg.Worker.MetricsPort)
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("server listening", "addr", server.Addr, "role", role)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		slog.Info("shutdown signal received")
+	case err := <-serverErr:
+		slog.Error("server failed", "error", err)
+	}
+	stop()
+
+	// 1️⃣ Stop menerima request, tunggu request yang sedang berjalan
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("server shutdown failed", "error", err)
 	}
+	cancel()
 
-	ctx := context.Background()
-	go app.Worker.Start(ctx, 5*time.Second)
+	// 2️⃣ Stop worker, job yang sedang berjalan diselesaikan dulu
+	cancelWorkers()
+	select {
+	case <-workerDone:
+	case <-time.After(cfg.Worker.ShutdownTimeout):
+		// job yang belum selesai dikembalikan ke antrian
+		slog.Warn("worker shutdown timed out")
+		released, err := app.Worker.Queue.Release(context.Background())
+		if err != nil {
+			slog.Error("release running jobs failed", "error", err)
+		} else if released > 0 {
+			slog.Warn("returned running jobs to the queue", "count", released)
+		}
+	}
+
+	// 3️⃣ Kirim span yang tersisa
+	tracingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("flush traces failed", "error", err)
+	}
+	cancel()
+
+	// 4️⃣ Tutup connection pool
+	if sqlDB, err := app.DB.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("close database failed", "error", err)
+		}
+	}
+
+	slog.Info("server stopped")
+}
 
-	app.Router.Run(":3000")
+// fatal logs err as JSON and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/cmd/wire"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
+)
+
+const migrateUsage = `usage: migrate [-dir migrations] <command>
+
+commands:
+  up           apply every pending migration
+  down [N]     roll back the last N migrations (default 1)
+  status       list migrations and when they were applied
+  baseline V   mark every migration up to version V as applied without
+               running it, for a schema that already exists
+  create NAME  write empty up and down files into -dir`
+
+// runMigrate handles `migrate <command>`, the SQL files are embedded in the
+// binary so it works without the source tree
+func runMigrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := flags.String("dir", "migrations", "directory for new migration files")
+	flags.Usage = func() { fmt.Fprintln(os.Stderr, migrateUsage) }
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	command, rest := flags.Arg(0), flags.Args()[1:]
+
+	// create tidak butuh database
+	if command == "create" {
+		if len(rest) != 1 {
+			fatal("create migration failed", errors.New("create needs exactly one NAME"))
+		}
+		up, down, err := migration.Create(*dir, rest[0], time.Now())
+		if err != nil {
+			fatal("create migration failed", err)
+		}
+		fmt.Println("created", up)
+		fmt.Println("created", down)
+		return
+	}
+
+	migrator, err := wire.InitializeMigrator()
+	if err != nil {
+		fatal("initialize migrator failed", err)
+	}
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fatal("migrate up failed", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+
+	case "down":
+		n := 1
+		if len(rest) > 0 {
+			if n, err = strconv.Atoi(rest[0]); err != nil {
+				fatal("migrate down failed", fmt.Errorf("invalid number of migrations %q", rest[0]))
+			}
+		}
+		reverted, err := migrator.Down(ctx, n)
+		for _, m := range reverted {
+			fmt.Printf("rolled back %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fatal("migrate down failed", err)
+		}
+
+	case "baseline":
+		if len(rest) != 1 {
+			fatal("migrate baseline failed", errors.New("baseline needs exactly one VERSION"))
+		}
+		version, err := strconv.ParseInt(rest[0], 10, 64)
+		if err != nil {
+			fatal("migrate baseline failed", fmt.Errorf("invalid version %q", rest[0]))
+		}
+		marked, err := migrator.Baseline(ctx, version)
+		for _, m := range marked {
+			fmt.Printf("marked %d_%s as applied\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fatal("migrate baseline failed", err)
+		}
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("migration status failed", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Printf("%-25s %d_%s\n", applied, s.Version, s.Name)
+		}
+
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+}
@@ -4,11 +4,21 @@
 package wire
 
 import (
+	"log/slog"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
 	"github.com/Mhbib34/missing-person-service/internal/controller"
 	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
 	"github.com/Mhbib34/missing-person-service/internal/repository"
 	"github.com/Mhbib34/missing-person-service/internal/router"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
 	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
 	"github.com/Mhbib34/missing-person-service/internal/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -16,46 +26,110 @@ import (
 	"gorm.io/gorm"
 )
 
-
 type App struct {
-	DB     *gorm.DB
-	Router *gin.Engine
-	Worker *worker.ResizeImageJobWorker
+	Config       *config.Config
+	Logger       *slog.Logger
+	DB           *gorm.DB
+	Migrator     *migration.Migrator
+	Router       *gin.Engine
+	Worker       *worker.Process
+	QueueMetrics *metrics.QueueCollector
 }
 
-func NewValidator() *validator.Validate {
-	return validator.New()
+// WorkerApp is a process that only runs jobs, without the HTTP layers
+type WorkerApp struct {
+	Config       *config.Config
+	Logger       *slog.Logger
+	DB           *gorm.DB
+	Migrator     *migration.Migrator
+	Worker       *worker.Process
+	QueueMetrics *metrics.QueueCollector
 }
 
+func NewValidator() (*validator.Validate, error) {
+	return validation.New()
+}
 
 var repositorySet = wire.NewSet(
 	repository.NewMissingPersonRepository,
+	repository.NewSightingRepository,
+	repository.NewUserRepository,
+	repository.NewJobRepository,
 )
 
 var usecaseSet = wire.NewSet(
 	usecase.NewMissingPersonUsecase,
+	usecase.NewSightingUsecase,
+	usecase.NewUserUsecase,
+	usecase.NewJobUsecase,
+	usecase.NewHealthUsecase,
 )
 
 var controllerSet = wire.NewSet(
 	controller.NewMissingPersonController,
+	controller.NewSightingController,
+	controller.NewUserController,
+	controller.NewJobController,
+	controller.NewHealthController,
 )
 
 var routerSet = wire.NewSet(
 	router.SetupRouter,
 )
 
-func provideResizeImageWorker(db *gorm.DB) *worker.ResizeImageJobWorker {
-	return worker.NewResizeImageJobWorker(db, 5)
+var workerSet = wire.NewSet(
+	provideQueue,
+	provideListener,
+	worker.NewReconciler,
+	wire.Struct(new(worker.Process), "*"),
+)
+
+// provideLogger builds the JSON logger from the log config
+func provideLogger(cfg *config.Config) *slog.Logger {
+	return logger.New(cfg.Log)
+}
+
+// provideStaging opens the upload staging directory shared by API and worker
+func provideStaging(cfg *config.Config) (*upload.Staging, error) {
+	return upload.NewStaging(cfg.Upload.StagingDir)
+}
+
+func provideQueue(cfg *config.Config, db *gorm.DB, imageStorage storage.ImageStorage, staging *upload.Staging) *worker.Queue {
+	queue := worker.NewQueue(db)
+	worker.RegisterImageJobs(queue, db, imageStorage, staging, cfg.Worker.Concurrency)
+	return queue
+}
+
+// provideQueueCollector reports the queue state read from the database
+func provideQueueCollector(jobs repository.JobRepository) *metrics.QueueCollector {
+	return metrics.NewQueueCollector(jobs.Stats)
+}
+
+func provideListener(cfg *config.Config, queue *worker.Queue) *worker.Listener {
+	return worker.NewListener(cfg.Database.DSN(), queue.Notify)
 }
 
 func InitializeServer() (*App, error) {
 	wire.Build(
+		// Config
+		config.Load,
+		provideLogger,
+
 		// Database
 		database.Connect,
+		migration.NewMigrator,
 
 		// Validator
 		NewValidator,
 
+		// Image storage & uploads
+		storage.NewImageStorage,
+		provideStaging,
+		upload.NewUploader,
+
+		// Auth
+		helper.NewTokenManager,
+
 		// Layers
 		repositorySet,
 		usecaseSet,
@@ -63,10 +137,53 @@ func InitializeServer() (*App, error) {
 		routerSet,
 
 		// Worker
-		provideResizeImageWorker,
+		workerSet,
+
+		// Metrics
+		provideQueueCollector,
 
 		// App struct
 		wire.Struct(new(App), "*"),
 	)
 	return nil, nil
 }
+
+func InitializeWorker() (*WorkerApp, error) {
+	wire.Build(
+		// Config, tanpa server dan auth
+		config.LoadWorker,
+		provideLogger,
+
+		// Database
+		database.Connect,
+		migration.NewMigrator,
+
+		// Image storage & uploads
+		storage.NewImageStorage,
+		provideStaging,
+
+		// Worker
+		workerSet,
+
+		// Metrics
+		repository.NewJobRepository,
+		provideQueueCollector,
+
+		// App struct
+		wire.Struct(new(WorkerApp), "*"),
+	)
+	return nil, nil
+}
+
+func InitializeMigrator() (*migration.Migrator, error) {
+	wire.Build(
+		// Config, migrate hanya butuh database
+		config.LoadDatabase,
+		provideLogger,
+
+		// Database
+		database.Connect,
+		migration.NewMigrator,
+	)
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+package wire
+
+import "fmt"
+
+// Role is what a process of cmd/api runs
+type Role string
+
+const (
+	RoleAPI    Role = "api"
+	RoleWorker Role = "worker"
+	RoleAll    Role = "all"
+)
+
+// ParseRole reads the -role flag
+func ParseRole(value string) (Role, error) {
+	switch role := Role(value); role {
+	case RoleAPI, RoleWorker, RoleAll:
+		return role, nil
+	}
+	return "", fmt.Errorf("unknown role %q, expected api, worker or all", value)
+}
+
+// ServesHTTP reports whether the role serves the API
+func (r Role) ServesHTTP() bool {
+	return r == RoleAPI || r == RoleAll
+}
+
+// RunsJobs reports whether the role runs the job worker
+func (r Role) RunsJobs() bool {
+	return r == RoleWorker || r == RoleAll
+}
+
+// InitializeRole builds the app of a role. The worker role uses the worker
+// graph, so it needs neither the HTTP layers nor their configuration; its
+// App has no Router.
+func InitializeRole(role Role) (*App, error) {
+	if role != RoleWorker {
+		return InitializeServer()
+	}
+
+	workerApp, err := InitializeWorker()
+	if err != nil {
+		return nil, err
+	}
+	return &App{
+		Config:       workerApp.Config,
+		Logger:       workerApp.Logger,
+		DB:           workerApp.DB,
+		Migrator:     workerApp.Migrator,
+		Worker:       workerApp.Worker,
+		QueueMetrics: workerApp.QueueMetrics,
+	}, nil
+}
@@ -7,59 +7,213 @@
 package wire
 
 import (
+	"github.com/Mhbib34/missing-person-service/internal/config"
 	"github.com/Mhbib34/missing-person-service/internal/controller"
 	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
 	"github.com/Mhbib34/missing-person-service/internal/repository"
 	"github.com/Mhbib34/missing-person-service/internal/router"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
 	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
 	"github.com/Mhbib34/missing-person-service/internal/worker"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	"log/slog"
 )
 
 // Injectors from injector.go:
 
 func InitializeServer() (*App, error) {
-	db, err := database.Connect()
+	configConfig, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	logger := provideLogger(configConfig)
+	db, err := database.Connect(configConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migration.NewMigrator(db)
 	if err != nil {
 		return nil, err
 	}
 	missingPersonRepository := repository.NewMissingPersonRepository(db)
-	validate := NewValidator()
-	missingPersonUsecase := usecase.NewMissingPersonUsecase(missingPersonRepository, validate)
+	validate, err := NewValidator()
+	if err != nil {
+		return nil, err
+	}
+	staging, err := provideStaging(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	uploader := upload.NewUploader(configConfig, staging)
+	missingPersonUsecase := usecase.NewMissingPersonUsecase(missingPersonRepository, validate, uploader)
 	missingPersonController := controller.NewMissingPersonController(missingPersonUsecase)
-	engine := router.SetupRouter(missingPersonController)
-	resizeImageJobWorker := provideResizeImageWorker(db)
+	sightingRepository := repository.NewSightingRepository(db)
+	sightingUsecase := usecase.NewSightingUsecase(sightingRepository, missingPersonRepository, validate, uploader)
+	sightingController := controller.NewSightingController(sightingUsecase)
+	userRepository := repository.NewUserRepository(db)
+	tokenManager := helper.NewTokenManager(configConfig)
+	userUsecase := usecase.NewUserUsecase(userRepository, validate, tokenManager)
+	userController := controller.NewUserController(userUsecase)
+	jobRepository := repository.NewJobRepository(db)
+	jobUsecase := usecase.NewJobUsecase(jobRepository)
+	jobController := controller.NewJobController(jobUsecase)
+	imageStorage, err := storage.NewImageStorage(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	healthUsecase := usecase.NewHealthUsecase(db, imageStorage, staging, migrator)
+	healthController := controller.NewHealthController(healthUsecase)
+	engine := router.SetupRouter(missingPersonController, sightingController, userController, jobController, healthController, imageStorage, uploader, tokenManager)
+	queue := provideQueue(configConfig, db, imageStorage, staging)
+	listener := provideListener(configConfig, queue)
+	reconciler := worker.NewReconciler(db, staging)
+	process := &worker.Process{
+		Queue:      queue,
+		Listener:   listener,
+		Reconciler: reconciler,
+	}
+	queueCollector := provideQueueCollector(jobRepository)
 	app := &App{
-		DB:     db,
-		Router: engine,
-		Worker: resizeImageJobWorker,
+		Config:       configConfig,
+		Logger:       logger,
+		DB:           db,
+		Migrator:     migrator,
+		Router:       engine,
+		Worker:       process,
+		QueueMetrics: queueCollector,
 	}
 	return app, nil
 }
 
+func InitializeWorker() (*WorkerApp, error) {
+	configConfig, err := config.LoadWorker()
+	if err != nil {
+		return nil, err
+	}
+	logger := provideLogger(configConfig)
+	db, err := database.Connect(configConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	imageStorage, err := storage.NewImageStorage(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	staging, err := provideStaging(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	queue := provideQueue(configConfig, db, imageStorage, staging)
+	listener := provideListener(configConfig, queue)
+	reconciler := worker.NewReconciler(db, staging)
+	process := &worker.Process{
+		Queue:      queue,
+		Listener:   listener,
+		Reconciler: reconciler,
+	}
+	jobRepository := repository.NewJobRepository(db)
+	queueCollector := provideQueueCollector(jobRepository)
+	workerApp := &WorkerApp{
+		Config:       configConfig,
+		Logger:       logger,
+		DB:           db,
+		Migrator:     migrator,
+		Worker:       process,
+		QueueMetrics: queueCollector,
+	}
+	return workerApp, nil
+}
+
+func InitializeMigrator() (*migration.Migrator, error) {
+	configConfig, err := config.LoadDatabase()
+	if err != nil {
+		return nil, err
+	}
+	logger := provideLogger(configConfig)
+	db, err := database.Connect(configConfig, logger)
+	if err != nil {
+		return nil, err
+	}
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return nil, err
+	}
+	return migrator, nil
+}
+
 // injector.go:
 
 type App struct {
-	DB     *gorm.DB
-	Router *gin.Engine
-	Worker *worker.ResizeImageJobWorker
+	Config       *config.Config
+	Logger       *slog.Logger
+	DB           *gorm.DB
+	Migrator     *migration.Migrator
+	Router       *gin.Engine
+	Worker       *worker.Process
+	QueueMetrics *metrics.QueueCollector
+}
+
+// WorkerApp is a process that only runs jobs, without the HTTP layers
+type WorkerApp struct {
+	Config       *config.Config
+	Logger       *slog.Logger
+	DB           *gorm.DB
+	Migrator     *migration.Migrator
+	Worker       *worker.Process
+	QueueMetrics *metrics.QueueCollector
 }
 
-func NewValidator() *validator.Validate {
-	return validator.New()
+func NewValidator() (*validator.Validate, error) {
+	return validation.New()
 }
 
-var repositorySet = wire.NewSet(repository.NewMissingPersonRepository)
+var repositorySet = wire.NewSet(repository.NewMissingPersonRepository, repository.NewSightingRepository, repository.NewUserRepository, repository.NewJobRepository)
 
-var usecaseSet = wire.NewSet(usecase.NewMissingPersonUsecase)
+var usecaseSet = wire.NewSet(usecase.NewMissingPersonUsecase, usecase.NewSightingUsecase, usecase.NewUserUsecase, usecase.NewJobUsecase, usecase.NewHealthUsecase)
 
-var controllerSet = wire.NewSet(controller.NewMissingPersonController)
+var controllerSet = wire.NewSet(controller.NewMissingPersonController, controller.NewSightingController, controller.NewUserController, controller.NewJobController, controller.NewHealthController)
 
 var routerSet = wire.NewSet(router.SetupRouter)
 
-func provideResizeImageWorker(db *gorm.DB) *worker.ResizeImageJobWorker {
-	return worker.NewResizeImageJobWorker(db, 5)
+var workerSet = wire.NewSet(
+	provideQueue,
+	provideListener, worker.NewReconciler, wire.Struct(new(worker.Process), "*"),
+)
+
+// provideLogger builds the JSON logger from the log config
+func provideLogger(cfg *config.Config) *slog.Logger {
+	return logger.New(cfg.Log)
+}
+
+// provideStaging opens the upload staging directory shared by API and worker
+func provideStaging(cfg *config.Config) (*upload.Staging, error) {
+	return upload.NewStaging(cfg.Upload.StagingDir)
+}
+
+func provideQueue(cfg *config.Config, db *gorm.DB, imageStorage storage.ImageStorage, staging *upload.Staging) *worker.Queue {
+	queue := worker.NewQueue(db)
+	worker.RegisterImageJobs(queue, db, imageStorage, staging, cfg.Worker.Concurrency)
+	return queue
+}
+
+// provideQueueCollector reports the queue state read from the database
+func provideQueueCollector(jobs repository.JobRepository) *metrics.QueueCollector {
+	return metrics.NewQueueCollector(jobs.Stats)
+}
+
+func provideListener(cfg *config.Config, queue *worker.Queue) *worker.Listener {
+	return worker.NewListener(cfg.Database.DSN(), queue.Notify)
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/cmd/wire"
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Worker tanpa HTTP, bisa di-scale terpisah dari API
+func main() {
+	// log sebelum config terbaca tetap JSON
+	slog.SetDefault(logger.New(config.LogConfig{Level: "info"}))
+
+	app, err := wire.InitializeWorker()
+	if err != nil {
+		fatal("initialize worker failed", err)
+	}
+	slog.SetDefault(app.Logger)
+
+	// jangan memproses job dengan schema yang tertinggal
+	if err := app.Migrator.Check(context.Background()); err != nil {
+		fatal("schema check failed", err)
+	}
+
+	prometheus.MustRegister(app.QueueMetrics)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), app.Config.Tracing)
+	if err != nil {
+		fatal("setup tracing failed", err)
+	}
+
+	// /metrics di port sendiri
+	metricsServer := metrics.NewServer(app.Config.Worker.MetricsPort)
+	go func() {
+		slog.Info("metrics listening", "addr", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "error", err)
+		}
+	}()
+
+	// SIGINT / SIGTERM memulai shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		// job baru dibangunkan lewat NOTIFY, poll hanya cadangan
+		app.Worker.Start(ctx, app.Config.Worker.PollInterval)
+	}()
+
+	<-ctx.Done()
+	slog.Info("shutdown signal received")
+
+	// job yang sedang berjalan diselesaikan dulu
+	select {
+	case <-workerDone:
+	case <-time.After(app.Config.Worker.ShutdownTimeout):
+		// job yang belum selesai dikembalikan ke antrian
+		slog.Warn("worker shutdown timed out")
+		released, err := app.Worker.Queue.Release(context.Background())
+		if err != nil {
+			slog.Error("release running jobs failed", "error", err)
+		} else if released > 0 {
+			slog.Warn("returned running jobs to the queue", "count", released)
+		}
+	}
+
+	// kirim span yang tersisa
+	tracingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := shutdownTracing(tracingCtx); err != nil {
+		slog.Error("flush traces failed", "error", err)
+	}
+	cancel()
+
+	if err := metricsServer.Close(); err != nil {
+		slog.Error("close metrics server failed", "error", err)
+	}
+
+	if sqlDB, err := app.DB.DB(); err == nil {
+		if err := sqlDB.Close(); err != nil {
+			slog.Error("close database failed", "error", err)
+		}
+	}
+
+	slog.Info("worker stopped")
+}
+
+// fatal logs err as JSON and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Config is the whole service configuration. Every value can come from the
+// YAML file (yaml tag) and be overridden by the environment (env tag).
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Storage  StorageConfig  `yaml:"storage"`
+	Upload   UploadConfig   `yaml:"upload"`
+	Worker   WorkerConfig   `yaml:"worker"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+}
+
+type ServerConfig struct {
+	Port int `yaml:"port" env:"PORT" validate:"min=1,max=65535"`
+
+	// time given to running requests on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" validate:"gt=0"`
+}
+
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST" validate:"required"`
+	Port     int    `yaml:"port" env:"DB_PORT" validate:"min=1,max=65535"`
+	User     string `yaml:"user" env:"DB_USER" validate:"required"`
+	Password string `yaml:"password" env:"DB_PASSWORD"`
+	Name     string `yaml:"name" env:"DB_NAME" validate:"required"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+}
+
+// DSN is the connection string for gorm and pgx
+func (c DatabaseConfig) DSN() string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%d sslmode=%s",
+		quoteDSN(c.Host),
+		quoteDSN(c.User),
+		quoteDSN(c.Password),
+		quoteDSN(c.Name),
+		c.Port,
+		quoteDSN(c.SSLMode),
+	)
+}
+
+// quoteDSN quotes a keyword/value connection string value, so passwords with
+// spaces, quotes or backslashes are passed as they are
+func quoteDSN(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret" env:"JWT_SECRET" validate:"required"`
+	JWTTTL    time.Duration `yaml:"jwt_ttl" env:"JWT_TTL" validate:"gt=0"`
+}
+
+type StorageConfig struct {
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER" validate:"oneof=cloudinary local s3"`
+
+	Local      LocalStorageConfig `yaml:"local"`
+	Cloudinary CloudinaryConfig   `yaml:"cloudinary"`
+	S3         S3Config           `yaml:"s3"`
+}
+
+type LocalStorageConfig struct {
+	Dir string `yaml:"dir" env:"LOCAL_STORAGE_DIR" validate:"required"`
+
+	// default http://localhost:<port>/images
+	URL string `yaml:"url" env:"LOCAL_STORAGE_URL" validate:"omitempty,url"`
+}
+
+type CloudinaryConfig struct {
+	CloudName string `yaml:"cloud_name" env:"CLOUDINARY_CLOUD_NAME"`
+	APIKey    string `yaml:"api_key" env:"CLOUDINARY_API_KEY"`
+	APISecret string `yaml:"api_secret" env:"CLOUDINARY_API_SECRET"`
+}
+
+type S3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"S3_ENDPOINT" validate:"required"`
+	UseSSL    bool   `yaml:"use_ssl" env:"S3_USE_SSL"`
+	AccessKey string `yaml:"access_key" env:"S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"S3_SECRET_KEY"`
+	Region    string `yaml:"region" env:"S3_REGION"`
+	Bucket    string `yaml:"bucket" env:"S3_BUCKET" validate:"required"`
+
+	// buat bucket publik saat start kalau belum ada, untuk MinIO lokal
+	CreateBucket bool `yaml:"create_bucket" env:"S3_CREATE_BUCKET"`
+
+	// default path-style URL ke endpoint
+	PublicURL string `yaml:"public_url" env:"S3_PUBLIC_URL" validate:"omitempty,url"`
+}
+
+type UploadConfig struct {
+	MaxSizeMB int `yaml:"max_size_mb" env:"UPLOAD_MAX_SIZE_MB" validate:"min=1"`
+
+	// uploads wait here for the worker, API and worker must share it
+	StagingDir string `yaml:"staging_dir" env:"UPLOAD_STAGING_DIR" validate:"required"`
+}
+
+type WorkerConfig struct {
+	Concurrency int `yaml:"concurrency" env:"WORKER_CONCURRENCY" validate:"min=1"`
+
+	// fallback poll, new jobs wake the worker through NOTIFY
+	PollInterval time.Duration `yaml:"poll_interval" env:"JOB_POLL_INTERVAL" validate:"gt=0"`
+
+	// time given to running jobs on shutdown
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"WORKER_SHUTDOWN_TIMEOUT" validate:"gt=0"`
+
+	// /metrics of a process without the API
+	MetricsPort int `yaml:"metrics_port" env:"WORKER_METRICS_PORT" validate:"min=1,max=65535"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL" validate:"oneof=debug info warn error"`
+}
+
+type TracingConfig struct {
+	// none turns tracing off, stdout prints the spans for local runs
+	Exporter string `yaml:"exporter" env:"TRACING_EXPORTER" validate:"oneof=none stdout otlp"`
+
+	// OTLP/HTTP traces endpoint of the collector
+	Endpoint string `yaml:"endpoint" env:"TRACING_ENDPOINT" validate:"url"`
+
+	ServiceName string `yaml:"service_name" env:"OTEL_SERVICE_NAME" validate:"required"`
+
+	// share of new traces that are recorded, a sampled request keeps its
+	// trace in the worker
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" validate:"gte=0,lte=1"`
+}
+
+// defaults match the values the service used before it had a config file
+func defaults() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            3000,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Port:    5432,
+			SSLMode: "disable",
+		},
+		Auth: AuthConfig{
+			JWTTTL: 24 * time.Hour,
+		},
+		Storage: StorageConfig{
+			Driver: "cloudinary",
+			Local: LocalStorageConfig{
+				Dir: "storage/images",
+			},
+			S3: S3Config{
+				Endpoint: "localhost:9000",
+				Bucket:   "missing-persons",
+			},
+		},
+		Upload: UploadConfig{
+			MaxSizeMB:  5,
+			StagingDir: "storage/tmp",
+		},
+		Worker: WorkerConfig{
+			Concurrency:     5,
+			PollInterval:    time.Minute,
+			ShutdownTimeout: 30 * time.Second,
+			MetricsPort:     9091,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			Endpoint:    "http://localhost:4318/v1/traces",
+			ServiceName: "missing-person-service",
+			SampleRatio: 1,
+		},
+	}
+}
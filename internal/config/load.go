@@ -0,0 +1,264 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFile is read when CONFIG_FILE is not set and the file exists
+const defaultFile = "config.yaml"
+
+// Load builds the configuration from the defaults, the YAML file and the
+// environment, later sources winning. A .env file is loaded into the
+// environment when present; variables that are already set are kept.
+func Load() (*Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// LoadDatabase reads the configuration like Load but only validates what a
+// database connection needs, for commands such as migrate that neither
+// serve requests nor touch the image storage
+func LoadDatabase() (*Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	problems, err := check(cfg.Database, cfg.Log)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, invalidConfig(problems)
+	}
+	return cfg, nil
+}
+
+// LoadWorker reads the configuration like Load but skips the HTTP server
+// and auth settings, a worker process neither serves requests nor checks
+// tokens
+func LoadWorker() (*Config, error) {
+	cfg, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(cfg.Database, cfg.Storage, cfg.Upload, cfg.Worker, cfg.Log, cfg.Tracing); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func read() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg := defaults()
+
+	// file yang disebut eksplisit wajib ada
+	path := os.Getenv("CONFIG_FILE")
+	explicit := path != ""
+	if !explicit {
+		path = defaultFile
+	}
+	if err := readFile(cfg, path, explicit); err != nil {
+		return nil, err
+	}
+
+	if err := applyEnv(reflect.ValueOf(cfg).Elem()); err != nil {
+		return nil, err
+	}
+
+	if cfg.Storage.Local.URL == "" {
+		cfg.Storage.Local.URL = fmt.Sprintf("http://localhost:%d/images", cfg.Server.Port)
+	}
+	if cfg.Storage.S3.PublicURL == "" {
+		scheme := "http://"
+		if cfg.Storage.S3.UseSSL {
+			scheme = "https://"
+		}
+		cfg.Storage.S3.PublicURL = scheme + cfg.Storage.S3.Endpoint + "/" + cfg.Storage.S3.Bucket
+	}
+	return cfg, nil
+}
+
+func readFile(cfg *Config, path string, required bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides every field that has an env tag and a non-empty
+// variable
+func applyEnv(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		tag := v.Type().Field(i).Tag.Get("env")
+
+		if field.Kind() == reflect.Struct {
+			if err := applyEnv(field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value := os.Getenv(tag)
+		if tag == "" || value == "" {
+			continue
+		}
+
+		if err := setField(field, value); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", tag, value, err)
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case string:
+		field.SetString(value)
+	case int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return errors.New("not a number")
+		}
+		field.SetInt(int64(n))
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("not a number")
+		}
+		field.SetFloat(f)
+	case bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("not a boolean")
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// Validate reports every invalid value at once, named by its environment
+// variable
+func (c *Config) Validate() error {
+	return c.validate(c)
+}
+
+// validate checks the given sections of c and the storage credentials
+func (c *Config) validate(sections ...any) error {
+	problems, err := check(sections...)
+	if err != nil {
+		return err
+	}
+
+	// credential hanya wajib untuk driver yang dipakai
+	required := func(name string, value string) {
+		if value == "" {
+			problems = append(problems, name+" is required when STORAGE_DRIVER is "+c.Storage.Driver)
+		}
+	}
+	switch c.Storage.Driver {
+	case "cloudinary":
+		required("CLOUDINARY_CLOUD_NAME", c.Storage.Cloudinary.CloudName)
+		required("CLOUDINARY_API_KEY", c.Storage.Cloudinary.APIKey)
+		required("CLOUDINARY_API_SECRET", c.Storage.Cloudinary.APISecret)
+	case "s3":
+		required("S3_ACCESS_KEY", c.Storage.S3.AccessKey)
+		required("S3_SECRET_KEY", c.Storage.S3.SecretKey)
+	}
+
+	if len(problems) > 0 {
+		return invalidConfig(problems)
+	}
+	return nil
+}
+
+// check runs the validate tags of every section and returns the problems
+func check(sections ...any) ([]string, error) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		if env := field.Tag.Get("env"); env != "" {
+			return env
+		}
+		return field.Name
+	})
+
+	var problems []string
+	for _, section := range sections {
+		err := validate.Struct(section)
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			for _, fe := range validationErrors {
+				problems = append(problems, message(fe))
+			}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return problems, nil
+}
+
+func invalidConfig(problems []string) error {
+	return errors.New("invalid configuration:\n  - " + strings.Join(problems, "\n  - "))
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fe.Field() + " is required"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s, got %q", fe.Field(), strings.ReplaceAll(fe.Param(), " ", ", "), fe.Value())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "gt":
+		return fe.Field() + " must be positive"
+	case "url":
+		return fe.Field() + " must be a valid URL"
+	default:
+		return fmt.Sprintf("%s is invalid (%s)", fe.Field(), fe.Tag())
+	}
+}
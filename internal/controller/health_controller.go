@@ -0,0 +1,8 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type HealthController interface {
+	Liveness(ctx *gin.Context)
+	Readiness(ctx *gin.Context)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type HealthControllerImpl struct {
+	usecase usecase.HealthUsecase
+}
+
+func NewHealthController(u usecase.HealthUsecase) HealthController {
+	return &HealthControllerImpl{usecase: u}
+}
+
+// Liveness only tells the process is up, dependencies are not checked
+func (c *HealthControllerImpl) Liveness(ctx *gin.Context) {
+	helper.WriteToResponseBody(ctx, http.StatusOK, dto.WebResponse{
+		Status:  "OK",
+		Message: "Service is alive",
+	})
+}
+
+func (c *HealthControllerImpl) Readiness(ctx *gin.Context) {
+	result, err := c.usecase.Readiness(ctx.Request.Context())
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	if !result.Ready {
+		helper.WriteToResponseBody(ctx, http.StatusServiceUnavailable, dto.WebResponse{
+			Code:    http.StatusServiceUnavailable,
+			Status:  "SERVICE UNAVAILABLE",
+			Message: "Service is not ready",
+			Data:    result,
+		})
+		return
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, dto.WebResponse{
+		Status:  "OK",
+		Message: "Service is ready",
+		Data:    result,
+	})
+}
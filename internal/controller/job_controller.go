@@ -0,0 +1,9 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type JobController interface {
+	GetAll(ctx *gin.Context)
+	Requeue(ctx *gin.Context)
+	WorkerStatus(ctx *gin.Context)
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type JobControllerImpl struct {
+	usecase usecase.JobUsecase
+}
+
+func NewJobController(u usecase.JobUsecase) JobController {
+	return &JobControllerImpl{usecase: u}
+}
+
+func (c *JobControllerImpl) GetAll(ctx *gin.Context) {
+	page := helper.StringToIntDefault(ctx.Query("page"), 1)
+	limit := helper.StringToIntDefault(ctx.Query("limit"), 10)
+
+	jobs, total, err := c.usecase.GetAll(ctx.Request.Context(), ctx.Query("status"), ctx.Query("kind"), page, limit)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Jobs retrieved successfully",
+		Data:    jobs,
+		Pagination: &dto.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *JobControllerImpl) Requeue(ctx *gin.Context) {
+	id, err := helper.StringToUUID(ctx.Param("id"))
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := c.usecase.Requeue(ctx.Request.Context(), id)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Job requeued successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *JobControllerImpl) WorkerStatus(ctx *gin.Context) {
+	result, err := c.usecase.WorkerStatus(ctx.Request.Context())
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Worker status retrieved successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
@@ -6,4 +6,11 @@ type MissingPersonController interface {
 	Create(ctx *gin.Context)
 	FindByID(ctx *gin.Context)
 	GetAll(ctx *gin.Context)
-}
\ No newline at end of file
+	GetModerationQueue(ctx *gin.Context)
+	Approve(ctx *gin.Context)
+	Reject(ctx *gin.Context)
+	FindNearby(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+}
@@ -3,8 +3,6 @@ package controller
 import (
 	"math"
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/Mhbib34/missing-person-service/internal/dto"
 	"github.com/Mhbib34/missing-person-service/internal/exception"
@@ -29,24 +27,18 @@ func (c *MissingPersonControllerImpl) Create(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.usecase.Create(ctx.Request.Context(), request)
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	result, err := c.usecase.Create(ctx.Request.Context(), principal, request)
 	if err != nil {
 		exception.ErrorHandler(ctx, err)
 		return
 	}
 
-	uploadDir := "storage/tmp"
-	_ = os.MkdirAll(uploadDir, 0755)
-
-	filename := request.Photo.Filename // atau uuid + ext
-	filePath := filepath.Join(uploadDir, filename)
-
-	ctx.SaveUploadedFile(request.Photo, filePath)
-
 	webResponse := dto.WebResponse{
-		Status: "OK",
+		Status:  "OK",
 		Message: "Report created successfully. Image is being processed.",
-		Data:   result,
+		Data:    result,
 	}
 
 	helper.WriteToResponseBody(ctx, http.StatusCreated, webResponse)
@@ -61,30 +53,75 @@ func (c *MissingPersonControllerImpl) FindByID(ctx *gin.Context) {
 		return
 	}
 
-	missingPerson, err := c.usecase.FindByID(ctx.Request.Context(), id)
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	missingPerson, err := c.usecase.FindByID(ctx.Request.Context(), principal, id)
 	if err != nil {
 		exception.ErrorHandler(ctx, err)
 		return
 	}
 
 	webResponse := dto.WebResponse{
-		Status: "OK",
+		Status:  "OK",
 		Message: "Report retrieved successfully",
-		Data:   missingPerson,
+		Data:    missingPerson,
 	}
 
 	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
 }
 
 func (c *MissingPersonControllerImpl) GetAll(ctx *gin.Context) {
+	var request dto.SearchMissingPersonRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	request.Page = helper.StringToIntDefault(ctx.Query("page"), 1)
+	request.Limit = helper.StringToIntDefault(ctx.Query("limit"), 10)
+
+	// default sort: relevansi kalau ada pencarian, selain itu terbaru
+	if request.Sort == "" {
+		request.Sort = "created_at"
+		if request.Query != "" {
+			request.Sort = "relevance"
+		}
+	}
+	if request.Order == "" {
+		request.Order = "desc"
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	missingPersons, total, err := c.usecase.GetAll(ctx.Request.Context(), principal, request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(request.Limit)))
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report retrieved successfully",
+		Data:    missingPersons,
+		Pagination: &dto.Pagination{
+			Page:       request.Page,
+			Limit:      request.Limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+			Filters:    request,
+		},
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) GetModerationQueue(ctx *gin.Context) {
 	page := helper.StringToIntDefault(ctx.Query("page"), 1)
 	limit := helper.StringToIntDefault(ctx.Query("limit"), 10)
 
-	missingPersons, total, err := c.usecase.GetAll(
-		ctx.Request.Context(),
-		page,
-		limit,
-	)
+	missingPersons, total, err := c.usecase.GetModerationQueue(ctx.Request.Context(), page, limit)
 	if err != nil {
 		exception.ErrorHandler(ctx, err)
 		return
@@ -94,7 +131,7 @@ func (c *MissingPersonControllerImpl) GetAll(ctx *gin.Context) {
 
 	webResponse := dto.WebResponse{
 		Status:  "OK",
-		Message: "Report retrieved successfully",
+		Message: "Moderation queue retrieved successfully",
 		Data:    missingPersons,
 		Pagination: &dto.Pagination{
 			Page:       page,
@@ -106,3 +143,176 @@ func (c *MissingPersonControllerImpl) GetAll(ctx *gin.Context) {
 
 	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
 }
+
+func (c *MissingPersonControllerImpl) Approve(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+
+	id, err := helper.StringToUUID(idParam)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	result, err := c.usecase.Approve(ctx.Request.Context(), principal, id)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report approved successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) Reject(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+
+	id, err := helper.StringToUUID(idParam)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	var request dto.RejectMissingPersonRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	result, err := c.usecase.Reject(ctx.Request.Context(), principal, id, request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report rejected successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) FindNearby(ctx *gin.Context) {
+	var request dto.NearbyMissingPersonRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	if request.RadiusKm == 0 {
+		request.RadiusKm = 10
+	}
+	request.Limit = helper.StringToIntDefault(ctx.Query("limit"), 20)
+
+	missingPersons, err := c.usecase.FindNearby(ctx.Request.Context(), request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report retrieved successfully",
+		Data:    missingPersons,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) Update(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+
+	id, err := helper.StringToUUID(idParam)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	var request dto.UpdateMissingPersonRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	result, err := c.usecase.Update(ctx.Request.Context(), principal, id, request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report updated successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) Delete(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+
+	id, err := helper.StringToUUID(idParam)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	var request dto.DeleteMissingPersonRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	if err := c.usecase.Delete(ctx.Request.Context(), principal, id, request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report retracted successfully",
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *MissingPersonControllerImpl) Restore(ctx *gin.Context) {
+	idParam := ctx.Param("id")
+
+	id, err := helper.StringToUUID(idParam)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	result, err := c.usecase.Restore(ctx.Request.Context(), principal, id)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Report restored successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
@@ -0,0 +1,8 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type SightingController interface {
+	Create(ctx *gin.Context)
+	FindByMissingPersonID(ctx *gin.Context)
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type SightingControllerImpl struct {
+	usecase usecase.SightingUsecase
+}
+
+func NewSightingController(u usecase.SightingUsecase) SightingController {
+	return &SightingControllerImpl{usecase: u}
+}
+
+func (c *SightingControllerImpl) Create(ctx *gin.Context) {
+	missingPersonID, err := helper.StringToUUID(ctx.Param("id"))
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	var request dto.CreateSightingRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := c.usecase.Create(ctx.Request.Context(), missingPersonID, request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	message := "Sighting reported successfully"
+	if request.Photo != nil {
+		message = "Sighting reported successfully. Image is being processed."
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: message,
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusCreated, webResponse)
+}
+
+func (c *SightingControllerImpl) FindByMissingPersonID(ctx *gin.Context) {
+	missingPersonID, err := helper.StringToUUID(ctx.Param("id"))
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	page := helper.StringToIntDefault(ctx.Query("page"), 1)
+	limit := helper.StringToIntDefault(ctx.Query("limit"), 10)
+
+	principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+	sightings, total, err := c.usecase.FindByMissingPersonID(ctx.Request.Context(), principal, missingPersonID, page, limit)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Sightings retrieved successfully",
+		Data:    sightings,
+		Pagination: &dto.Pagination{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
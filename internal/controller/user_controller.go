@@ -0,0 +1,9 @@
+package controller
+
+import "github.com/gin-gonic/gin"
+
+type UserController interface {
+	Register(ctx *gin.Context)
+	Login(ctx *gin.Context)
+	UpdateRole(ctx *gin.Context)
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type UserControllerImpl struct {
+	usecase usecase.UserUsecase
+}
+
+func NewUserController(u usecase.UserUsecase) UserController {
+	return &UserControllerImpl{usecase: u}
+}
+
+func (c *UserControllerImpl) Register(ctx *gin.Context) {
+	var request dto.RegisterRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := c.usecase.Register(ctx.Request.Context(), request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "User registered successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusCreated, webResponse)
+}
+
+func (c *UserControllerImpl) Login(ctx *gin.Context) {
+	var request dto.LoginRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := c.usecase.Login(ctx.Request.Context(), request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "Login successful",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
+
+func (c *UserControllerImpl) UpdateRole(ctx *gin.Context) {
+	id, err := helper.StringToUUID(ctx.Param("id"))
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	var request dto.UpdateUserRoleRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	result, err := c.usecase.UpdateRole(ctx.Request.Context(), id, request)
+	if err != nil {
+		exception.ErrorHandler(ctx, err)
+		return
+	}
+
+	webResponse := dto.WebResponse{
+		Status:  "OK",
+		Message: "User role updated successfully",
+		Data:    result,
+	}
+
+	helper.WriteToResponseBody(ctx, http.StatusOK, webResponse)
+}
@@ -1,31 +1,52 @@
 package database
 
 import (
-	"fmt"
-	"os"
+	"log/slog"
+	"time"
 
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-func Connect() (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_SSLMODE"),
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+// slowQuery is logged as a warning at every log level
+const slowQuery = 200 * time.Millisecond
+
+func Connect(cfg *config.Config, log *slog.Logger) (*gorm.DB, error) {
+	// setiap query hanya ditulis di level debug
+	level := logger.Warn
+	if cfg.Log.Level == "debug" {
+		level = logger.Info
+	}
+
+	db, err := gorm.Open(postgres.Open(cfg.Database.DSN()), &gorm.Config{
+		// tanpa nilai parameter, data pelapor tidak boleh masuk log
+		Logger: logger.NewSlogLogger(log, logger.Config{
+			LogLevel:                  level,
+			SlowThreshold:             slowQuery,
+			ParameterizedQueries:      true,
+			IgnoreRecordNotFoundError: true,
+		}),
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if err := metrics.InstrumentGorm(db); err != nil {
+		return nil, err
+	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
+
+// Notify sends a Postgres notification. Inside a transaction it is only
+// delivered once the transaction commits.
+func Notify(tx *gorm.DB, channel string, payload string) error {
+	return tx.Exec("SELECT pg_notify(?, ?)", channel, payload).Error
+}
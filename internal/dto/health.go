@@ -0,0 +1,8 @@
+package dto
+
+type ReadinessResponse struct {
+	Ready bool `json:"ready"`
+
+	// "ok" or "fail" per dependency, the reason is only logged
+	Checks map[string]string `json:"checks"`
+}
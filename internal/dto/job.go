@@ -0,0 +1,31 @@
+package dto
+
+import "encoding/json"
+
+type JobResponse struct {
+	ID        string          `json:"id"`
+	Kind      string          `json:"kind"`
+	Payload   json.RawMessage `json:"payload"`
+	Priority  int             `json:"priority"`
+	Status    string          `json:"status"`
+	Attempts  int             `json:"attempts"`
+	LastError string          `json:"last_error,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	RunAt     string          `json:"run_at"`
+	ClaimedBy string          `json:"claimed_by,omitempty"`
+	ClaimedAt string          `json:"claimed_at,omitempty"`
+	CreatedAt string          `json:"created_at"`
+	UpdatedAt string          `json:"updated_at"`
+}
+
+type WorkerStatusResponse struct {
+	Busy       int64            `json:"busy"`
+	BusyByKind map[string]int64 `json:"busy_by_kind"`
+
+	// rows waiting for the worker by image_status
+	QueueDepth map[string]int64 `json:"queue_depth"`
+
+	OldestPendingAt         string  `json:"oldest_pending_at,omitempty"`
+	OldestPendingAgeSeconds float64 `json:"oldest_pending_age_seconds"`
+	LastSuccessAt           string  `json:"last_success_at,omitempty"`
+}
@@ -7,18 +7,90 @@ type CreateMissingPersonRequest struct {
 	Age         int                   `form:"age" validate:"required,gt=0"`
 	Description string                `form:"description" validate:"required"`
 	LastSeen    string                `form:"last_seen" validate:"required"`
+	Latitude    *float64              `form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64              `form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	PlaceName   string                `form:"place_name" validate:"max=255"`
+	City        string                `form:"city" validate:"max=100"`
+	Province    string                `form:"province" validate:"max=100"`
 	Contact     string                `form:"contact" validate:"required"`
 	Photo       *multipart.FileHeader `form:"photo" validate:"required"`
 }
 
+type UpdateMissingPersonRequest struct {
+	Name        *string  `json:"name" form:"name" validate:"omitempty,min=1,max=100"`
+	Age         *int     `json:"age" form:"age" validate:"omitempty,gt=0"`
+	Description *string  `json:"description" form:"description" validate:"omitempty,min=1"`
+	LastSeen    *string  `json:"last_seen" form:"last_seen" validate:"omitempty,min=1,max=255"`
+	Latitude    *float64 `json:"latitude" form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	PlaceName   *string  `json:"place_name" form:"place_name" validate:"omitempty,max=255"`
+	City        *string  `json:"city" form:"city" validate:"omitempty,max=100"`
+	Province    *string  `json:"province" form:"province" validate:"omitempty,max=100"`
+	Contact     *string  `json:"contact" form:"contact" validate:"omitempty,min=1,max=100"`
+	CaseStatus  *string  `json:"case_status" form:"case_status" validate:"omitempty,oneof=open found_safe found_deceased withdrawn closed"`
+}
+
+type DeleteMissingPersonRequest struct {
+	Reason string `json:"reason" form:"reason" validate:"required"`
+}
+
+type RejectMissingPersonRequest struct {
+	Reason string `json:"reason" form:"reason" validate:"required,max=1000"`
+}
+
+type PhotosResponse struct {
+	Thumbnail string `json:"thumbnail"`
+	Card      string `json:"card"`
+	Full      string `json:"full"`
+}
+
 type MissingPersonResponse struct {
-	ID          string `json:"id"`
-	Name        string `json:"name,omitempty"`
-	Age         int    `json:"age,omitempty"`
-	Description string `json:"description,omitempty"`
-	LastSeen    string `json:"last_seen,omitempty"`
-	Contact     string `json:"contact,omitempty"`
-	PhotoID     string `json:"photo_id,omitempty"`
-	ImageStatus string `json:"image_status,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-}
\ No newline at end of file
+	ID               string          `json:"id"`
+	Name             string          `json:"name,omitempty"`
+	Age              int             `json:"age,omitempty"`
+	Description      string          `json:"description,omitempty"`
+	LastSeen         string          `json:"last_seen,omitempty"`
+	Latitude         *float64        `json:"latitude,omitempty"`
+	Longitude        *float64        `json:"longitude,omitempty"`
+	PlaceName        string          `json:"place_name,omitempty"`
+	City             string          `json:"city,omitempty"`
+	Province         string          `json:"province,omitempty"`
+	Contact          string          `json:"contact,omitempty"`
+	ReporterID       string          `json:"reporter_id,omitempty"`
+	CaseStatus       string          `json:"case_status,omitempty"`
+	ModerationStatus string          `json:"moderation_status,omitempty"`
+	RejectionReason  string          `json:"rejection_reason,omitempty"`
+	Photos           *PhotosResponse `json:"photos,omitempty"`
+	ImageStatus      string          `json:"image_status,omitempty"`
+	CreatedAt        string          `json:"created_at,omitempty"`
+	UpdatedAt        string          `json:"updated_at,omitempty"`
+}
+
+// SearchMissingPersonRequest is bound from the list query string and echoed
+// back in the pagination block so clients can render the applied filters.
+type SearchMissingPersonRequest struct {
+	Page  int `form:"-" json:"-"`
+	Limit int `form:"-" json:"-"`
+
+	Query        string   `form:"q" json:"q,omitempty" validate:"max=200"`
+	MinAge       int      `form:"min_age" json:"min_age,omitempty" validate:"omitempty,gt=0"`
+	MaxAge       int      `form:"max_age" json:"max_age,omitempty" validate:"omitempty,gt=0,gtefield=MinAge"`
+	CreatedFrom  string   `form:"created_from" json:"created_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo    string   `form:"created_to" json:"created_to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CaseStatuses []string `form:"case_status" collection_format:"csv" json:"case_status,omitempty" validate:"dive,oneof=open found_safe found_deceased withdrawn closed"`
+
+	Sort  string `form:"sort" json:"sort" validate:"omitempty,oneof=created_at name age relevance"`
+	Order string `form:"order" json:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+type NearbyMissingPersonRequest struct {
+	Latitude  *float64 `form:"lat" validate:"required,gte=-90,lte=90"`
+	Longitude *float64 `form:"lng" validate:"required,gte=-180,lte=180"`
+	RadiusKm  float64  `form:"radius_km" validate:"omitempty,gt=0,lte=500"`
+	Limit     int      `form:"-"`
+}
+
+type NearbyMissingPersonResponse struct {
+	MissingPersonResponse
+	DistanceKm float64 `json:"distance_km"`
+}
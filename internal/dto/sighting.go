@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+)
+
+type CreateSightingRequest struct {
+	SeenAt          time.Time             `form:"seen_at" time_format:"2006-01-02T15:04:05Z07:00" validate:"required,lte"`
+	Location        string                `form:"location" validate:"required,max=255"`
+	Latitude        *float64              `form:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude       *float64              `form:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	Description     string                `form:"description" validate:"required"`
+	ReporterName    string                `form:"reporter_name" validate:"max=100"`
+	ReporterContact string                `form:"reporter_contact" validate:"required,max=100"`
+	Photo           *multipart.FileHeader `form:"photo"`
+}
+
+// SightingResponse is a sighting, the witness fields are only filled for
+// the witness itself, the owner of the report and moderators
+type SightingResponse struct {
+	ID              string          `json:"id"`
+	MissingPersonID string          `json:"missing_person_id"`
+	SeenAt          string          `json:"seen_at"`
+	Location        string          `json:"location"`
+	Latitude        *float64        `json:"latitude,omitempty"`
+	Longitude       *float64        `json:"longitude,omitempty"`
+	Description     string          `json:"description"`
+	Photos          *PhotosResponse `json:"photos,omitempty"`
+	ImageStatus     string          `json:"image_status,omitempty"`
+	CreatedAt       string          `json:"created_at,omitempty"`
+	ReporterName    string          `json:"reporter_name,omitempty"`
+	ReporterContact string          `json:"reporter_contact,omitempty"`
+}
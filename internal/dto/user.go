@@ -0,0 +1,31 @@
+package dto
+
+type RegisterRequest struct {
+	Name     string `json:"name" form:"name" validate:"required,max=100"`
+	Email    string `json:"email" form:"email" validate:"required,email,max=255"`
+	Password string `json:"password" form:"password" validate:"required,min=8,max=72"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" form:"email" validate:"required,email"`
+	Password string `json:"password" form:"password" validate:"required"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" form:"role" validate:"required,oneof=reporter moderator admin"`
+}
+
+type UserResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+type LoginResponse struct {
+	Token     string       `json:"token"`
+	TokenType string       `json:"token_type"`
+	ExpiresAt string       `json:"expires_at"`
+	User      UserResponse `json:"user"`
+}
@@ -14,4 +14,24 @@ type Pagination struct {
 	Limit      int `json:"limit,omitempty"`
 	Total      int `json:"total,omitempty"`
 	TotalPages int `json:"total_pages,omitempty"`
-}
\ No newline at end of file
+	Filters    any `json:"filters,omitempty"`
+}
+
+// ProblemResponse is an RFC 7807 problem details body, sent as
+// application/problem+json
+type ProblemResponse struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a rule a request field failed
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
@@ -5,10 +5,11 @@ import (
 
 	"github.com/Mhbib34/missing-person-service/internal/model"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type MissingPersons struct {
-	ID  uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	Name        string
 	Age         int
 	Description string
@@ -16,5 +17,6 @@ type MissingPersons struct {
 	Contact     string
 	PhotoID     string
 	ImageStatus model.ImageStatus
-	CreatedAt time.Time 
-}
\ No newline at end of file
+	CreatedAt   time.Time
+	DeletedAt   gorm.DeletedAt
+}
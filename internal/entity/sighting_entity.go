@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+)
+
+type Sightings struct {
+	ID              uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	MissingPersonID uuid.UUID
+	PhotoID         string
+	ImageStatus     model.ImageStatus
+	CreatedAt       time.Time
+}
@@ -0,0 +1,24 @@
+package exception
+
+// BadRequestError means the request itself is malformed, such as an unreadable photo
+type BadRequestError struct {
+	Message string
+	Err     error
+}
+
+func (e BadRequestError) Error() string {
+	return e.Message
+}
+
+func (e BadRequestError) Unwrap() error {
+	return e.Err
+}
+
+func NewBadRequestError(message string) BadRequestError {
+	return BadRequestError{Message: message}
+}
+
+// WrapBadRequestError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapBadRequestError(err error, message string) BadRequestError {
+	return BadRequestError{Message: message, Err: err}
+}
@@ -1,13 +1,24 @@
 package exception
 
+// ConflictError means the request clashes with the current state of the resource
 type ConflictError struct {
 	Message string
+	Err     error
 }
 
 func (e ConflictError) Error() string {
 	return e.Message
 }
 
+func (e ConflictError) Unwrap() error {
+	return e.Err
+}
+
 func NewConflictError(message string) ConflictError {
 	return ConflictError{Message: message}
-}
\ No newline at end of file
+}
+
+// WrapConflictError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapConflictError(err error, message string) ConflictError {
+	return ConflictError{Message: message, Err: err}
+}
@@ -1,10 +0,0 @@
-package exception
-
-import "log"
-
-func PanicIfError(err error) {
-	if err != nil {
-		log.Println("ERROR:", err)
-		panic(err)
-	}
-}
\ No newline at end of file
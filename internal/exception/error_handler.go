@@ -1,92 +1,170 @@
 package exception
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"net/http"
+	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/Mhbib34/missing-person-service/internal/dto"
 	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"gorm.io/gorm"
 )
 
-func ErrorHandler(ctx *gin.Context, err any) {
-
-	if notFoundError(ctx, err) {
-		return
-	}
-
-	if validationErrors(ctx, err) {
-		return
-	}
-
-	if conflictError(ctx, err) {
-		return
-	}
-
-	internalServerError(ctx, err)
+// ProblemContentType is the media type of error responses, RFC 7807
+const ProblemContentType = "application/problem+json"
+
+// problemTitles is the title per problem type, the type URI of a problem is
+// /problems/<type>
+var problemTitles = map[string]string{
+	"validation-error":  "Validation Failed",
+	"malformed-request": "Malformed Request",
+	"bad-request":       "Bad Request",
+	"unauthorized":      "Unauthorized",
+	"forbidden":         "Forbidden",
+	"not-found":         "Not Found",
+	"conflict":          "Conflict",
+	"payload-too-large": "Payload Too Large",
+	"rate-limited":      "Too Many Requests",
+	"unavailable":       "Service Unavailable",
+	"internal-error":    "Internal Server Error",
 }
 
-func validationErrors(ctx *gin.Context, err any) bool {
-	ex, ok := err.(validator.ValidationErrors)
-	if ok {
-
-		webResponse := dto.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Error:  ex.Error(),
+// ErrorHandler writes the problem response for err. The typed errors of this
+// package are found with errors.As so they may be wrapped, anything else is a
+// 500.
+func ErrorHandler(ctx *gin.Context, err error) {
+	var (
+		invalid          ValidationError
+		validationErrors validator.ValidationErrors
+		syntax           *json.SyntaxError
+		unmarshalType    *json.UnmarshalTypeError
+		number           *strconv.NumError
+		parseTime        *time.ParseError
+		badRequest       BadRequestError
+		unauthorized     UnauthorizedError
+		forbidden        ForbiddenError
+		notFound         NotFoundError
+		conflict         ConflictError
+		payloadTooLarge  PayloadTooLargeError
+		maxBytes         *http.MaxBytesError
+		rateLimited      RateLimitedError
+		unavailable      UnavailableError
+	)
+
+	switch {
+	case errors.As(err, &validationErrors):
+		detail := "request validation failed"
+		if errors.As(err, &invalid) {
+			detail = invalid.Error()
 		}
-
-		helper.WriteToResponseBody(ctx, http.StatusBadRequest, webResponse)
-		return true
-	}
-	return false
-}
-
-
-func notFoundError(ctx *gin.Context, err any) bool {
-	if e, ok := err.(error); ok {
-			
-		if errors.Is(e, gorm.ErrRecordNotFound) {
-			webResponse := dto.WebResponse{
-				Code:   http.StatusNotFound,
-				Status: "NOT FOUND",
-				Error:  "Report not found",
-			}
-
-			helper.WriteToResponseBody(ctx, http.StatusNotFound, webResponse)
-			return true
+		fields := translateFields(ctx, validationErrors)
+		writeProblem(ctx, http.StatusBadRequest, "validation-error", detail, fields)
+	case errors.As(err, &invalid):
+		writeProblem(ctx, http.StatusBadRequest, "validation-error", invalid.Error(), nil)
+
+	// error dari binding request sebelum sampai ke usecase
+	case errors.As(err, &syntax), errors.Is(err, io.ErrUnexpectedEOF):
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", "request body is not valid JSON", nil)
+	case errors.Is(err, io.EOF):
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", "request body is empty", nil)
+	case errors.As(err, &unmarshalType):
+		if unmarshalType.Field == "" {
+			writeProblem(ctx, http.StatusBadRequest, "malformed-request", "request body must be a JSON "+jsonType(unmarshalType.Type), nil)
+			break
 		}
+		fields := []dto.FieldError{{
+			Field:   unmarshalType.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("%s must be a %s", unmarshalType.Field, jsonType(unmarshalType.Type)),
+		}}
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", "request body has a value of the wrong type", fields)
+	case errors.As(err, &number):
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", fmt.Sprintf("%q is not a valid number", number.Num), nil)
+	case errors.As(err, &parseTime):
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", fmt.Sprintf("%q is not a valid time, use %s", parseTime.Value, time.RFC3339), nil)
+	case errors.Is(err, helper.ErrInvalidUUID):
+		writeProblem(ctx, http.StatusBadRequest, "malformed-request", "id must be a valid UUID", nil)
+
+	case errors.As(err, &badRequest):
+		writeProblem(ctx, http.StatusBadRequest, "bad-request", badRequest.Error(), nil)
+	case errors.As(err, &unauthorized):
+		writeProblem(ctx, http.StatusUnauthorized, "unauthorized", unauthorized.Error(), nil)
+	case errors.As(err, &forbidden):
+		writeProblem(ctx, http.StatusForbidden, "forbidden", forbidden.Error(), nil)
+	case errors.As(err, &notFound):
+		writeProblem(ctx, http.StatusNotFound, "not-found", notFound.Error(), nil)
+	case errors.As(err, &conflict):
+		writeProblem(ctx, http.StatusConflict, "conflict", conflict.Error(), nil)
+	case errors.As(err, &payloadTooLarge):
+		writeProblem(ctx, http.StatusRequestEntityTooLarge, "payload-too-large", payloadTooLarge.Error(), nil)
+	case errors.As(err, &maxBytes):
+		// body yang melewati batas http.MaxBytesReader
+		writeProblem(ctx, http.StatusRequestEntityTooLarge, "payload-too-large", fmt.Sprintf("request body must not be larger than %d bytes", maxBytes.Limit), nil)
+	case errors.As(err, &rateLimited):
+		if rateLimited.RetryAfter > 0 {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateLimited.RetryAfter.Seconds()))))
+		}
+		writeProblem(ctx, http.StatusTooManyRequests, "rate-limited", rateLimited.Error(), nil)
+	case errors.As(err, &unavailable):
+		slog.WarnContext(ctx.Request.Context(), "service unavailable", "error", unavailable.Err)
+		writeProblem(ctx, http.StatusServiceUnavailable, "unavailable", unavailable.Error(), nil)
+	default:
+		internalServerError(ctx, err)
 	}
-	return false
 }
 
-func conflictError(ctx *gin.Context, err any) bool {
-	ex, ok := err.(ConflictError)
-	if ok {
-
-		webResponse := dto.WebResponse{
-			Code:   http.StatusConflict,
-			Status: "CONFLICT",
-			Error:  ex.Error(),
-		}
+// internalServerError hides the cause from the client, it is only logged
+func internalServerError(ctx *gin.Context, err error) {
+	slog.ErrorContext(ctx.Request.Context(), "internal server error", "error", err)
+	writeProblem(ctx, http.StatusInternalServerError, "internal-error", "internal server error", nil)
+}
 
-		helper.WriteToResponseBody(ctx, http.StatusConflict, webResponse)
-		return true
+// jsonType names the JSON type a Go type is decoded from
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
 	}
-	return false
 }
 
+// translateFields reports the failed rules in the language of the client
+func translateFields(ctx *gin.Context, err validator.ValidationErrors) []dto.FieldError {
+	trans := validation.Translator(ctx.GetHeader("Accept-Language"))
+	return validation.FieldErrors(err, trans)
+}
 
-func internalServerError(ctx *gin.Context, err any) {
-
-	webResponse := dto.WebResponse{
-		Code:   http.StatusInternalServerError,
-		Status: "INTERNAL SERVER ERROR",
-		Error:  fmt.Sprintf("%v", err),
+func writeProblem(ctx *gin.Context, status int, problemType string, detail string, fields []dto.FieldError) {
+	problem := dto.ProblemResponse{
+		Type:      "/problems/" + problemType,
+		Title:     problemTitles[problemType],
+		Status:    status,
+		Detail:    detail,
+		Instance:  ctx.Request.URL.Path,
+		RequestID: logger.RequestID(ctx.Request.Context()),
+		Errors:    fields,
 	}
 
-	helper.WriteToResponseBody(ctx, http.StatusInternalServerError, webResponse)
-}
\ No newline at end of file
+	// gin hanya mengisi Content-Type yang belum di-set
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.JSON(status, problem)
+}
@@ -0,0 +1,24 @@
+package exception
+
+// ForbiddenError means the caller is authenticated but may not do this
+type ForbiddenError struct {
+	Message string
+	Err     error
+}
+
+func (e ForbiddenError) Error() string {
+	return e.Message
+}
+
+func (e ForbiddenError) Unwrap() error {
+	return e.Err
+}
+
+func NewForbiddenError(message string) ForbiddenError {
+	return ForbiddenError{Message: message}
+}
+
+// WrapForbiddenError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapForbiddenError(err error, message string) ForbiddenError {
+	return ForbiddenError{Message: message, Err: err}
+}
@@ -0,0 +1,24 @@
+package exception
+
+// NotFoundError means the resource does not exist or is hidden from the caller
+type NotFoundError struct {
+	Message string
+	Err     error
+}
+
+func (e NotFoundError) Error() string {
+	return e.Message
+}
+
+func (e NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+func NewNotFoundError(message string) NotFoundError {
+	return NotFoundError{Message: message}
+}
+
+// WrapNotFoundError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapNotFoundError(err error, message string) NotFoundError {
+	return NotFoundError{Message: message, Err: err}
+}
@@ -0,0 +1,24 @@
+package exception
+
+// PayloadTooLargeError means the request body or an uploaded file is too big
+type PayloadTooLargeError struct {
+	Message string
+	Err     error
+}
+
+func (e PayloadTooLargeError) Error() string {
+	return e.Message
+}
+
+func (e PayloadTooLargeError) Unwrap() error {
+	return e.Err
+}
+
+func NewPayloadTooLargeError(message string) PayloadTooLargeError {
+	return PayloadTooLargeError{Message: message}
+}
+
+// WrapPayloadTooLargeError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapPayloadTooLargeError(err error, message string) PayloadTooLargeError {
+	return PayloadTooLargeError{Message: message, Err: err}
+}
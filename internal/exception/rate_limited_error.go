@@ -0,0 +1,23 @@
+package exception
+
+import "time"
+
+// RateLimitedError means the caller sent too many requests, RetryAfter is
+// sent back in the Retry-After header when set
+type RateLimitedError struct {
+	Message    string
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e RateLimitedError) Error() string {
+	return e.Message
+}
+
+func (e RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+func NewRateLimitedError(message string, retryAfter time.Duration) RateLimitedError {
+	return RateLimitedError{Message: message, RetryAfter: retryAfter}
+}
@@ -0,0 +1,24 @@
+package exception
+
+// UnauthorizedError means the caller is not authenticated
+type UnauthorizedError struct {
+	Message string
+	Err     error
+}
+
+func (e UnauthorizedError) Error() string {
+	return e.Message
+}
+
+func (e UnauthorizedError) Unwrap() error {
+	return e.Err
+}
+
+func NewUnauthorizedError(message string) UnauthorizedError {
+	return UnauthorizedError{Message: message}
+}
+
+// WrapUnauthorizedError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapUnauthorizedError(err error, message string) UnauthorizedError {
+	return UnauthorizedError{Message: message, Err: err}
+}
@@ -0,0 +1,24 @@
+package exception
+
+// UnavailableError means a dependency such as the database or image storage is down, the request may succeed later
+type UnavailableError struct {
+	Message string
+	Err     error
+}
+
+func (e UnavailableError) Error() string {
+	return e.Message
+}
+
+func (e UnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func NewUnavailableError(message string) UnavailableError {
+	return UnavailableError{Message: message}
+}
+
+// WrapUnavailableError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapUnavailableError(err error, message string) UnavailableError {
+	return UnavailableError{Message: message, Err: err}
+}
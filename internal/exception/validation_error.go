@@ -0,0 +1,24 @@
+package exception
+
+// ValidationError means the request failed validation, Err is usually validator.ValidationErrors
+type ValidationError struct {
+	Message string
+	Err     error
+}
+
+func (e ValidationError) Error() string {
+	return e.Message
+}
+
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func NewValidationError(message string) ValidationError {
+	return ValidationError{Message: message}
+}
+
+// WrapValidationError keeps err as the cause for errors.Is, errors.As and the logs
+func WrapValidationError(err error, message string) ValidationError {
+	return ValidationError{Message: message, Err: err}
+}
@@ -7,10 +7,13 @@ import (
 	"github.com/google/uuid"
 )
 
+// ErrInvalidUUID is returned by StringToUUID for a malformed id
+var ErrInvalidUUID = errors.New("invalid uuid format")
+
 func StringToUUID(id string) (uuid.UUID, error) {
 	parsedID, err := uuid.Parse(id)
 	if err != nil {
-		return uuid.Nil, errors.New("invalid uuid format")
+		return uuid.Nil, ErrInvalidUUID
 	}
 
 	return parsedID, nil
@@ -7,14 +7,11 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func ReadFromRequestBody(r *http.Request, result any) {
+func ReadFromRequestBody(r *http.Request, result any) error {
 	decoder := json.NewDecoder(r.Body)
-	err := decoder.Decode(result)
-	if err != nil {
-		panic(err)
-	}
+	return decoder.Decode(result)
 }
 
 func WriteToResponseBody(ctx *gin.Context, status int, data any) {
 	ctx.JSON(status, data)
-}
\ No newline at end of file
+}
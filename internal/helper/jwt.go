@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const jwtIssuer = "missing-person-service"
+
+type JWTClaims struct {
+	Role model.Role `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager signs and verifies the bearer tokens of the API
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(cfg *config.Config) *TokenManager {
+	return &TokenManager{
+		secret: []byte(cfg.Auth.JWTSecret),
+		ttl:    cfg.Auth.JWTTTL,
+	}
+}
+
+// GenerateToken signs an HS256 token for the user.
+func (m *TokenManager) GenerateToken(user model.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{
+		Role: user.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    jwtIssuer,
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, expiresAt, nil
+}
+
+// ParseToken verifies an HS256 token locally and returns its caller.
+func (m *TokenManager) ParseToken(tokenString string) (model.Principal, error) {
+	var claims JWTClaims
+	_, err := jwt.ParseWithClaims(
+		tokenString,
+		&claims,
+		func(token *jwt.Token) (any, error) {
+			return m.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(jwtIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return model.Principal{}, err
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return model.Principal{}, errors.New("invalid token subject")
+	}
+
+	return model.Principal{UserID: userID, Role: claims.Role}, nil
+}
@@ -1,20 +1,148 @@
 package helper
 
 import (
+	"time"
+
 	"github.com/Mhbib34/missing-person-service/internal/dto"
 	"github.com/Mhbib34/missing-person-service/internal/model"
 )
 
 func ToMissingPersonResponse(user model.MissingPersons) dto.MissingPersonResponse {
+	var reporterID string
+	if user.ReporterID != nil {
+		reporterID = user.ReporterID.String()
+	}
+
 	return dto.MissingPersonResponse{
-		ID:          user.ID.String(),
-		Name:        user.Name,
-		Age:         user.Age,
-		Description: user.Description,
-		LastSeen:    user.LastSeen,
-		Contact:     user.Contact,
-		PhotoID:     user.PhotoID,
-		ImageStatus: string(user.ImageStatus),
-		CreatedAt:   user.CreatedAt.String(),
-	}
-}
\ No newline at end of file
+		ID:               user.ID.String(),
+		Name:             user.Name,
+		Age:              user.Age,
+		Description:      user.Description,
+		LastSeen:         user.LastSeen,
+		Latitude:         user.Latitude,
+		Longitude:        user.Longitude,
+		PlaceName:        user.PlaceName,
+		City:             user.City,
+		Province:         user.Province,
+		Contact:          user.Contact,
+		ReporterID:       reporterID,
+		CaseStatus:       string(user.CaseStatus),
+		ModerationStatus: string(user.ModerationStatus),
+		RejectionReason:  user.RejectionReason,
+		Photos:           ToPhotosResponse(user.Photos),
+		ImageStatus:      string(user.ImageStatus),
+		CreatedAt:        user.CreatedAt.String(),
+		UpdatedAt:        user.UpdatedAt.String(),
+	}
+}
+
+func ToMissingPersonResponses(missingPersons []model.MissingPersons) []dto.MissingPersonResponse {
+	responses := make([]dto.MissingPersonResponse, 0, len(missingPersons))
+	for _, missingPerson := range missingPersons {
+		responses = append(responses, ToMissingPersonResponse(missingPerson))
+	}
+	return responses
+}
+
+// ToPhotosResponse returns nil until the worker has stored the renditions
+func ToPhotosResponse(photos model.Photos) *dto.PhotosResponse {
+	if photos.Full == "" {
+		return nil
+	}
+
+	return &dto.PhotosResponse{
+		Thumbnail: photos.Thumbnail,
+		Card:      photos.Card,
+		Full:      photos.Full,
+	}
+}
+
+func ToSightingResponse(sighting model.Sighting) dto.SightingResponse {
+	return dto.SightingResponse{
+		ID:              sighting.ID.String(),
+		MissingPersonID: sighting.MissingPersonID.String(),
+		SeenAt:          sighting.SeenAt.String(),
+		Location:        sighting.Location,
+		Latitude:        sighting.Latitude,
+		Longitude:       sighting.Longitude,
+		Description:     sighting.Description,
+		Photos:          ToPhotosResponse(sighting.Photos),
+		ImageStatus:     string(sighting.ImageStatus),
+		CreatedAt:       sighting.CreatedAt.String(),
+		ReporterName:    sighting.ReporterName,
+		ReporterContact: sighting.ReporterContact,
+	}
+}
+
+func ToSightingResponses(sightings []model.Sighting) []dto.SightingResponse {
+	responses := make([]dto.SightingResponse, 0, len(sightings))
+	for _, sighting := range sightings {
+		responses = append(responses, ToSightingResponse(sighting))
+	}
+	return responses
+}
+
+func ToUserResponse(user model.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:        user.ID.String(),
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      string(user.Role),
+		CreatedAt: user.CreatedAt.String(),
+	}
+}
+
+func ToJobResponse(job model.Job) dto.JobResponse {
+	var claimedAt string
+	if job.ClaimedAt != nil {
+		claimedAt = job.ClaimedAt.String()
+	}
+
+	return dto.JobResponse{
+		ID:        job.ID.String(),
+		Kind:      job.Kind,
+		Payload:   job.Payload,
+		Priority:  job.Priority,
+		Status:    string(job.Status),
+		Attempts:  job.Attempts,
+		LastError: job.LastError,
+		RequestID: job.RequestID,
+		RunAt:     job.RunAt.String(),
+		ClaimedBy: job.ClaimedBy,
+		ClaimedAt: claimedAt,
+		CreatedAt: job.CreatedAt.String(),
+		UpdatedAt: job.UpdatedAt.String(),
+	}
+}
+
+func ToJobResponses(jobs []model.Job) []dto.JobResponse {
+	responses := make([]dto.JobResponse, 0, len(jobs))
+	for _, job := range jobs {
+		responses = append(responses, ToJobResponse(job))
+	}
+	return responses
+}
+
+func ToWorkerStatusResponse(stats model.JobStats, now time.Time) dto.WorkerStatusResponse {
+	response := dto.WorkerStatusResponse{
+		BusyByKind: stats.BusyByKind,
+		QueueDepth: map[string]int64{},
+	}
+
+	for _, count := range stats.BusyByKind {
+		response.Busy += count
+	}
+	for status, count := range stats.ImageStatuses {
+		response.QueueDepth[string(status)] = count
+	}
+
+	if stats.OldestPendingAt != nil {
+		response.OldestPendingAt = stats.OldestPendingAt.String()
+		response.OldestPendingAgeSeconds = now.Sub(*stats.OldestPendingAt).Seconds()
+	}
+	if stats.LastSuccessAt != nil {
+		response.LastSuccessAt = stats.LastSuccessAt.String()
+	}
+
+	return response
+}
@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+)
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal model.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the caller set by the auth middleware, or an
+// anonymous principal.
+func PrincipalFromContext(ctx context.Context) model.Principal {
+	principal, _ := ctx.Value(principalKey{}).(model.Principal)
+	return principal
+}
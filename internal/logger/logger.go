@@ -0,0 +1,113 @@
+// Package logger sets up the JSON slog logger of the service. Attributes
+// stored in the context, such as the request ID, and the trace ID are added
+// to every record logged with that context.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// redacted replaces the value of PII attributes
+const redacted = "[REDACTED]"
+
+// piiKeys are attribute keys whose value is never written, in any group
+var piiKeys = map[string]bool{
+	"name":             true,
+	"contact":          true,
+	"reporter_name":    true,
+	"reporter_contact": true,
+	"email":            true,
+	"password":         true,
+}
+
+// New returns a JSON logger writing to stdout at the configured level
+func New(cfg config.LogConfig) *slog.Logger {
+	return NewWithWriter(os.Stdout, cfg)
+}
+
+// NewWithWriter is New writing to w, used by tests
+func NewWithWriter(w io.Writer, cfg config.LogConfig) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	})
+	return slog.New(contextHandler{handler})
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if piiKeys[a.Key] {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+type attrsKey struct{}
+
+type requestIDKey struct{}
+
+// WithAttrs returns a context whose log records carry attrs as well
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// WithRequestID stores the request ID for RequestID and adds it to the log
+// records of the context
+func WithRequestID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return WithAttrs(ctx, slog.String("request_id", id))
+}
+
+// RequestID returns the ID stored by WithRequestID, or an empty string
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// contextHandler adds the attributes stored in the context, and the IDs of
+// its trace, to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", span.TraceID().String()),
+			slog.String("span_id", span.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,98 @@
+// Package metrics holds the Prometheus collectors of the service. They are
+// registered on the default registry and served on /metrics.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "missing_person"
+
+// HTTP
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Database
+var (
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Duration of gorm operations by operation and table.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	DBQueryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "Failed gorm operations by operation and table, record not found excluded.",
+	}, []string{"operation", "table"})
+)
+
+// Worker
+var (
+	JobsClaimed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_claimed_total",
+		Help:      "Jobs claimed by this instance.",
+	}, []string{"kind"})
+
+	JobsSucceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_succeeded_total",
+		Help:      "Jobs finished successfully.",
+	}, []string{"kind"})
+
+	JobsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_failed_total",
+		Help:      "Failed job attempts, dead_letter is true for the last attempt.",
+	}, []string{"kind", "dead_letter"})
+
+	JobsBusy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "jobs_busy",
+		Help:      "Worker goroutines running a job right now.",
+	}, []string{"kind"})
+
+	JobDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Job processing time by result.",
+		Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"kind", "result"})
+
+	UploadBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "image_upload_bytes_total",
+		Help:      "Bytes uploaded to the image storage by rendition.",
+	}, []string{"rendition"})
+)
+
+// NewServer serves /metrics on its own port, for processes that do not run
+// the API
+func NewServer(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return &http.Server{
+		Addr:    fmt.Sprintf(":%d", port),
+		Handler: mux,
+	}
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// statsTimeout bounds the query run on every scrape
+const statsTimeout = 5 * time.Second
+
+// QueueCollector reads the queue state from the database on every scrape, so
+// the numbers cover all instances, not only this one
+type QueueCollector struct {
+	stats func(ctx context.Context) (*model.JobStats, error)
+
+	depth         *prometheus.Desc
+	oldestPending *prometheus.Desc
+}
+
+func NewQueueCollector(stats func(ctx context.Context) (*model.JobStats, error)) *QueueCollector {
+	return &QueueCollector{
+		stats: stats,
+		depth: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "image_queue_depth"),
+			"Reports and sightings by image_status, ready and deleted excluded.",
+			[]string{"image_status"}, nil,
+		),
+		oldestPending: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "job_oldest_pending_age_seconds"),
+			"Age of the oldest pending job, 0 when none is pending.",
+			nil, nil,
+		),
+	}
+}
+
+func (c *QueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.depth
+	ch <- c.oldestPending
+}
+
+func (c *QueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statsTimeout)
+	defer cancel()
+
+	stats, err := c.stats(ctx)
+	if err != nil {
+		slog.Error("collect queue metrics failed", "error", err)
+		return
+	}
+
+	// status tanpa row tetap dilaporkan sebagai 0
+	for _, status := range []model.ImageStatus{model.Pending, model.Processing, model.Deleting, model.Failed} {
+		ch <- prometheus.MustNewConstMetric(c.depth, prometheus.GaugeValue, float64(stats.ImageStatuses[status]), string(status))
+	}
+
+	age := 0.0
+	if stats.OldestPendingAt != nil {
+		age = time.Since(*stats.OldestPendingAt).Seconds()
+	}
+	ch <- prometheus.MustNewConstMetric(c.oldestPending, prometheus.GaugeValue, age)
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// Authenticate reads an optional "Authorization: Bearer <jwt>" header and
+// stores the caller in the request context. Requests without the header
+// continue as anonymous public readers; invalid tokens are rejected.
+func Authenticate(tokens *helper.TokenManager) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader("Authorization")
+		if header == "" {
+			ctx.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			exception.ErrorHandler(ctx, exception.NewUnauthorizedError("invalid authorization header"))
+			ctx.Abort()
+			return
+		}
+
+		principal, err := tokens.ParseToken(token)
+		if err != nil {
+			exception.ErrorHandler(ctx, exception.NewUnauthorizedError("invalid or expired token"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Request = ctx.Request.WithContext(helper.WithPrincipal(ctx.Request.Context(), principal))
+		ctx.Next()
+	}
+}
+
+// RequireRole only lets authenticated callers with one of the given roles
+// through. Without roles any authenticated caller is accepted.
+func RequireRole(roles ...model.Role) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal := helper.PrincipalFromContext(ctx.Request.Context())
+
+		if !principal.IsAuthenticated() {
+			exception.ErrorHandler(ctx, exception.NewUnauthorizedError("authentication required"))
+			ctx.Abort()
+			return
+		}
+
+		if len(roles) > 0 && !slices.Contains(roles, principal.Role) {
+			exception.ErrorHandler(ctx, exception.NewForbiddenError("insufficient role"))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/gin-gonic/gin"
+)
+
+// LimitBodySize rejects request bodies larger than the limit returned by
+// maxBytes before they are read into memory or onto disk.
+func LimitBodySize(maxBytes func() int64) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		limit := maxBytes()
+
+		if ctx.Request.ContentLength > limit {
+			exception.ErrorHandler(ctx, exception.NewPayloadTooLargeError(
+				fmt.Sprintf("request body must not be larger than %d bytes", limit),
+			))
+			ctx.Abort()
+			return
+		}
+
+		// Content-Length bisa tidak ada (chunked), jadi tetap dibatasi saat dibaca
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, limit)
+		ctx.Next()
+	}
+}
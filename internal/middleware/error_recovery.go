@@ -1,15 +1,22 @@
 package middleware
 
 import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+
 	"github.com/Mhbib34/missing-person-service/internal/exception"
 	"github.com/gin-gonic/gin"
 )
 
+// ErrorRecovery turns a panic, always a bug since errors are returned, into
+// a 500 response instead of a dropped connection
 func ErrorRecovery() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
-			if err := recover(); err != nil {
-				exception.ErrorHandler(ctx, err)
+			if r := recover(); r != nil {
+				slog.ErrorContext(ctx.Request.Context(), "panic while handling request", "panic", fmt.Sprint(r), "stack", string(debug.Stack()))
+				exception.ErrorHandler(ctx, fmt.Errorf("panic: %v", r))
 				ctx.Abort()
 			}
 		}()
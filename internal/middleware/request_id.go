@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds IDs sent by clients, longer ones are replaced
+const maxRequestIDLength = 128
+
+// RequestID reuses the X-Request-ID sent by a proxy or creates one, echoes it
+// in the response and stores it in the request context for the logs
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		ctx.Header(RequestIDHeader, id)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// serverName is the HTTP server name on the request spans
+const serverName = "missing-person-api"
+
+// untraced paths are polled by orchestrators and Prometheus
+var untraced = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+// Tracing starts a span for every request, continuing the trace of an
+// incoming traceparent header
+func Tracing() gin.HandlerFunc {
+	return otelgin.Middleware(serverName, otelgin.WithFilter(func(req *http.Request) bool {
+		return !untraced[req.URL.Path]
+	}))
+}
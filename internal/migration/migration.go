@@ -0,0 +1,111 @@
+package migration
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Migration is a pair of <version>_<name>.up.sql and .down.sql files
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var (
+	fileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	nameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// versionLayout is the timestamp used as version by Create
+const versionLayout = "20060102150405"
+
+// Parse reads every migration in fsys, ordered by version
+func Parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	files := map[int64]int{}
+	for _, entry := range entries {
+		match := fileRe.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+
+		files[version]++
+		if match[3] == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if files[m.Version] != 2 {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Create writes an empty up and down file for a new migration in dir and
+// returns their paths
+func Create(dir string, name string, now time.Time) (string, string, error) {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", "_"))
+	if !nameRe.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q, use lowercase letters, digits and underscores", name)
+	}
+
+	base := filepath.Join(dir, now.UTC().Format(versionLayout)+"_"+name)
+	up := base + ".up.sql"
+	down := base + ".down.sql"
+
+	for _, path := range []string{up, down} {
+		// jangan timpa file yang sudah ada
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			return "", "", err
+		}
+		if err := file.Close(); err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
@@ -0,0 +1,282 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/migrations"
+	"gorm.io/gorm"
+)
+
+// table records the applied migrations. It is not schema_migrations, that
+// name belongs to golang-migrate which keeps (version, dirty) in it.
+const table = "app_schema_migrations"
+
+// lockName is hashed into the advisory lock key, so two instances never
+// migrate at the same time
+const lockName = table
+
+const createTable = `CREATE TABLE IF NOT EXISTS ` + table + ` (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+)`
+
+const (
+	insertRecord = "INSERT INTO " + table + " (version, name) VALUES ($1, $2)"
+	deleteRecord = "DELETE FROM " + table + " WHERE version = $1"
+)
+
+// ErrSchemaBehind means the database is missing migrations the binary knows
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Status is a known migration with the time it was applied, nil when pending
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator applies the SQL migrations and records them in app_schema_migrations.
+// Every migration runs in its own transaction together with its record.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator uses the migrations embedded in the binary
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	list, err := Parse(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: list}, nil
+}
+
+// Up applies every pending migration in order
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if err := apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the last n applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("down needs a positive number of migrations, got %d", n)
+	}
+
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < n; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it, for a database whose schema was created before this
+// migrator, e.g. by hand or by another tool
+func (m *Migrator) Baseline(ctx context.Context, version int64) ([]Migration, error) {
+	known := false
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("baseline: unknown migration version %d", version)
+	}
+
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if _, err := conn.ExecContext(ctx, insertRecord, migration.Version, migration.Name); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every known migration in order
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedVersions(ctx, sqlDB)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Check returns ErrSchemaBehind when a known migration is not applied yet.
+// Migrations applied by a newer binary are fine, they are expected while a
+// deploy rolls out.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d pending migration(s), run `migrate up`", ErrSchemaBehind, pending)
+	}
+	return nil
+}
+
+// withLock runs fn on a single connection holding the advisory lock, an
+// advisory lock belongs to the session that took it
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return err
+	}
+
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock(hashtext($1))", lockName); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock(hashtext($1))", lockName)
+
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func appliedVersions(ctx context.Context, q querier) (map[int64]time.Time, error) {
+	// database baru belum punya tabelnya
+	var exists bool
+	if err := q.QueryRowContext(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+		return nil, err
+	}
+
+	applied := map[int64]time.Time{}
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := q.QueryContext(ctx, "SELECT version, applied_at FROM "+table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	script, record := migration.Down, deleteRecord
+	args := []any{migration.Version}
+	if up {
+		script, record = migration.Up, insertRecord
+		args = append(args, migration.Name)
+	}
+
+	// tanpa argumen pgx memakai simple protocol, jadi satu file boleh
+	// berisi beberapa statement
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
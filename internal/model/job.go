@@ -0,0 +1,119 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type JobStatus string
+
+const (
+	JobPending    JobStatus = "pending"
+	JobProcessing JobStatus = "processing"
+	JobDone       JobStatus = "done"
+
+	// dead-letter state after the last attempt failed, only an admin
+	// re-queue brings the job back
+	JobFailed JobStatus = "failed"
+)
+
+// JobsChannel is the Postgres channel notified with the job kind whenever a
+// job is enqueued, so idle workers pick it up right away
+const JobsChannel = "jobs"
+
+// Job kinds, every kind has its own handler in the worker
+const (
+	// resize and upload a newly stored photo
+	JobImageProcess = "image.process"
+	// remove the photo of a retracted report from the image storage
+	JobImageDelete = "image.delete"
+)
+
+// Job is an entry of the background job queue. Jobs that belong to a row are
+// written in the same transaction as the row, so a committed row always has
+// its job and a rolled back row never has one.
+type Job struct {
+	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+
+	Kind    string          `gorm:"type:varchar(50);not null;index:idx_jobs_claim,priority:2" json:"kind"`
+	Payload json.RawMessage `gorm:"type:jsonb;not null;default:'{}'" json:"payload"`
+
+	// jobs with the same ordering key run one after another in the order
+	// they were created, empty means no ordering
+	OrderingKey string `gorm:"type:varchar(255);not null;default:'';index" json:"ordering_key,omitempty"`
+
+	// higher runs first within the same kind
+	Priority int `gorm:"not null;default:0" json:"priority"`
+
+	// ID of the request that enqueued the job, carried into the job logs
+	RequestID string `gorm:"type:varchar(100);not null;default:''" json:"request_id,omitempty"`
+
+	// W3C trace context of the request, the job span joins its trace
+	TraceContext map[string]string `gorm:"type:jsonb;serializer:json" json:"-"`
+
+	Status JobStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_jobs_claim,priority:1;index:idx_jobs_status_lease_expires_at,priority:1" json:"status"`
+
+	// Schedule & Retry Info
+	RunAt     time.Time `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_jobs_claim,priority:3" json:"run_at"`
+	Attempts  int       `gorm:"not null;default:0" json:"attempts"`
+	LastError string    `gorm:"type:text" json:"last_error,omitempty"`
+
+	// Lease Info, the worker holding a job keeps extending the lease while
+	// it works; an expired lease means the worker is gone
+	ClaimedAt      *time.Time `json:"claimed_at,omitempty"`
+	ClaimedBy      string     `gorm:"type:varchar(100)" json:"claimed_by,omitempty"`
+	LeaseExpiresAt *time.Time `gorm:"index:idx_jobs_status_lease_expires_at,priority:2" json:"lease_expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// JobStats is a snapshot of the queue across every worker instance
+type JobStats struct {
+	// processing jobs with a live lease, each keeps one worker goroutine busy
+	BusyByKind map[string]int64
+
+	// rows of the photo tables by image_status, ready and deleted excluded
+	ImageStatuses map[ImageStatus]int64
+
+	OldestPendingAt *time.Time
+	LastSuccessAt   *time.Time
+}
+
+// Tables whose rows carry a photo handled by image jobs
+const (
+	SourceMissingPersons = "missing_persons"
+	SourceSightings      = "sightings"
+)
+
+// ImageJobPayload is the payload of image.process and image.delete jobs
+type ImageJobPayload struct {
+	SourceTable string    `json:"source_table"`
+	SourceID    uuid.UUID `json:"source_id"`
+	PhotoID     string    `json:"photo_id"`
+}
+
+// ImageJobKey orders the image jobs of one row, so a photo is never removed
+// before its upload finished
+func ImageJobKey(sourceTable string, sourceID uuid.UUID) string {
+	return sourceTable + ":" + sourceID.String()
+}
+
+// NewImageJob builds a pending image job for the photo of a row
+func NewImageJob(kind string, sourceTable string, sourceID uuid.UUID, photoID string) Job {
+	// payload hanya string dan uuid, marshal tidak bisa gagal
+	payload, _ := json.Marshal(ImageJobPayload{
+		SourceTable: sourceTable,
+		SourceID:    sourceID,
+		PhotoID:     photoID,
+	})
+
+	return Job{
+		Kind:        kind,
+		Payload:     payload,
+		OrderingKey: ImageJobKey(sourceTable, sourceID),
+		Status:      JobPending,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type ImageStatus string
@@ -13,25 +14,134 @@ const (
 	Processing ImageStatus = "processing"
 	Ready      ImageStatus = "ready"
 	Failed     ImageStatus = "failed"
+
+	// asset removal after a report is retracted
+	Deleting ImageStatus = "deleting"
+	Deleted  ImageStatus = "deleted"
+)
+
+// Photos holds the URLs of the resized renditions of a processed photo
+type Photos struct {
+	Thumbnail string `gorm:"type:varchar(512)" json:"thumbnail,omitempty"`
+	Card      string `gorm:"type:varchar(512)" json:"card,omitempty"`
+	Full      string `gorm:"type:varchar(512)" json:"full,omitempty"`
+}
+
+type CaseStatus string
+
+const (
+	Open          CaseStatus = "open"
+	FoundSafe     CaseStatus = "found_safe"
+	FoundDeceased CaseStatus = "found_deceased"
+	Withdrawn     CaseStatus = "withdrawn"
+	Closed        CaseStatus = "closed"
 )
 
+// caseStatusTransitions lists the statuses a report may move to from its
+// current status. Closed is terminal.
+var caseStatusTransitions = map[CaseStatus][]CaseStatus{
+	Open:          {FoundSafe, FoundDeceased, Withdrawn, Closed},
+	FoundSafe:     {Open, Closed},
+	FoundDeceased: {Closed},
+	Withdrawn:     {Open, Closed},
+	Closed:        {},
+}
 
+// HiddenCaseStatuses are excluded from the public listing unless the caller
+// explicitly filters on them.
+var HiddenCaseStatuses = []CaseStatus{FoundSafe, FoundDeceased, Closed}
 
+func (s CaseStatus) CanTransitionTo(next CaseStatus) bool {
+	for _, allowed := range caseStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ModerationStatus string
+
+const (
+	PendingReview ModerationStatus = "pending_review"
+	Approved      ModerationStatus = "approved"
+	Rejected      ModerationStatus = "rejected"
+)
 
 type MissingPersons struct {
 	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 
 	// Personal Info
 	Name        string `gorm:"type:varchar(100);not null" json:"name"`
-	Age         int    `gorm:"type:int" json:"age"`
+	Age         int    `gorm:"type:int;index" json:"age"`
 	Description string `gorm:"type:text;not null" json:"description"`
 	LastSeen    string `gorm:"type:varchar(255);not null" json:"last_seen"`
 	Contact     string `gorm:"type:varchar(100);not null" json:"contact"`
 
+	// Last Seen Location
+	Latitude  *float64 `gorm:"type:double precision;index:idx_missing_persons_location,priority:1" json:"latitude,omitempty"`
+	Longitude *float64 `gorm:"type:double precision;index:idx_missing_persons_location,priority:2" json:"longitude,omitempty"`
+	PlaceName string   `gorm:"type:varchar(255)" json:"place_name,omitempty"`
+	City      string   `gorm:"type:varchar(100)" json:"city,omitempty"`
+	Province  string   `gorm:"type:varchar(100)" json:"province,omitempty"`
+
+	// Reporter (owner of the report)
+	ReporterID *uuid.UUID `gorm:"type:uuid;index" json:"reporter_id,omitempty"`
+	Reporter   *User      `gorm:"foreignKey:ReporterID;constraint:OnDelete:SET NULL" json:"-"`
+
+	// Case Info
+	CaseStatus CaseStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"case_status"`
+
+	// Moderation Info, only approved reports are public
+	ModerationStatus ModerationStatus `gorm:"type:varchar(20);not null;default:'pending_review';index" json:"moderation_status"`
+	RejectionReason  string           `gorm:"type:text" json:"rejection_reason,omitempty"`
+	ModeratedBy      *uuid.UUID       `gorm:"type:uuid" json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time       `json:"moderated_at,omitempty"`
+
 	// Image Info
-	PhotoID     string      `gorm:"type:varchar(255);not null" json:"photo_id"` // Cloudinary public_id
+	PhotoID     string      `gorm:"type:varchar(255);not null" json:"-"` // uploaded file name
+	Photos      Photos      `gorm:"embedded;embeddedPrefix:photo_" json:"photos"`
 	ImageStatus ImageStatus `gorm:"type:varchar(20);default:'pending'" json:"image_status"`
 
+	// Full-text search, generated by postgres from name, description and last_seen
+	SearchVector string `gorm:"type:tsvector GENERATED ALWAYS AS (setweight(to_tsvector('simple', coalesce(name, '')), 'A') || setweight(to_tsvector('simple', coalesce(description, '')), 'B') || setweight(to_tsvector('simple', coalesce(last_seen, '')), 'C')) STORED;->:false;<-:false;index:idx_missing_persons_search_vector,type:gin" json:"-"`
+
+	// Retraction Info
+	RetractedBy      *uuid.UUID `gorm:"type:uuid" json:"retracted_by,omitempty"`
+	RetractionReason string     `gorm:"type:text" json:"retraction_reason,omitempty"`
+
+	// Restore Info, the retraction info above is kept for the audit trail
+	RestoredBy *uuid.UUID `gorm:"type:uuid" json:"restored_by,omitempty"`
+	RestoredAt *time.Time `json:"restored_at,omitempty"`
+
 	// Timestamps
-	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+	CreatedAt time.Time      `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// MissingPersonDistance is a report returned by a radius query together with
+// its distance from the search point.
+type MissingPersonDistance struct {
+	MissingPersons
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// MissingPersonFilter holds the search options for listing reports.
+type MissingPersonFilter struct {
+	Page  int
+	Limit int
+
+	Query        string
+	MinAge       int
+	MaxAge       int
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time // exclusive
+	CaseStatuses []CaseStatus
+
+	// empty means any moderation status
+	ModerationStatuses []ModerationStatus
+
+	SortBy   string
+	SortDesc bool
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Sighting struct {
+	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+
+	MissingPersonID uuid.UUID      `gorm:"type:uuid;not null;index" json:"missing_person_id"`
+	MissingPerson   MissingPersons `gorm:"foreignKey:MissingPersonID;constraint:OnDelete:CASCADE" json:"-"`
+
+	// Sighting Info
+	SeenAt      time.Time `gorm:"not null" json:"seen_at"`
+	Location    string    `gorm:"type:varchar(255);not null" json:"location"`
+	Latitude    *float64  `gorm:"type:double precision" json:"latitude,omitempty"`
+	Longitude   *float64  `gorm:"type:double precision" json:"longitude,omitempty"`
+	Description string    `gorm:"type:text;not null" json:"description"`
+
+	// Reporter Info
+	ReporterName    string `gorm:"type:varchar(100)" json:"reporter_name,omitempty"`
+	ReporterContact string `gorm:"type:varchar(100);not null" json:"reporter_contact"`
+
+	// Image Info (optional)
+	PhotoID     string      `gorm:"type:varchar(255)" json:"-"`
+	Photos      Photos      `gorm:"embedded;embeddedPrefix:photo_" json:"photos"`
+	ImageStatus ImageStatus `gorm:"type:varchar(20)" json:"image_status,omitempty"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleReporter  Role = "reporter"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type User struct {
+	ID uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+
+	Name         string `gorm:"type:varchar(100);not null" json:"name"`
+	Email        string `gorm:"type:varchar(255);not null;uniqueIndex" json:"email"`
+	PasswordHash string `gorm:"type:varchar(255);not null" json:"-"`
+	Role         Role   `gorm:"type:varchar(20);not null;default:'reporter'" json:"role"`
+
+	// Timestamps
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Principal is the caller of a request. The zero value is an anonymous
+// public reader.
+type Principal struct {
+	UserID uuid.UUID
+	Role   Role
+}
+
+func (p Principal) IsAuthenticated() bool {
+	return p.UserID != uuid.Nil
+}
+
+// IsModerator reports whether the caller may act on any report.
+func (p Principal) IsModerator() bool {
+	return p.Role == RoleModerator || p.Role == RoleAdmin
+}
+
+// Owns reports whether the caller filed the given report.
+func (p Principal) Owns(missingPerson MissingPersons) bool {
+	return p.IsAuthenticated() && missingPerson.ReporterID != nil && *missingPerson.ReporterID == p.UserID
+}
+
+// CanContactWitnesses reports whether the caller may see who reported the
+// sightings of the given report and how to reach them.
+func (p Principal) CanContactWitnesses(missingPerson MissingPersons) bool {
+	return p.Owns(missingPerson) || p.IsModerator()
+}
+
+// CanView reports whether the caller may see the given report. Reports that
+// are not approved yet are only visible to their reporter and moderators.
+func (p Principal) CanView(missingPerson MissingPersons) bool {
+	return missingPerson.ModerationStatus == Approved || p.Owns(missingPerson) || p.IsModerator()
+}
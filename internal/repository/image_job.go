@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// enqueueImageJob writes an image job as part of the caller's transaction,
+// workers are notified when the transaction commits
+func enqueueImageJob(tx *gorm.DB, kind string, sourceTable string, sourceID uuid.UUID, photoID string) error {
+	job := model.NewImageJob(kind, sourceTable, sourceID, photoID)
+	job.RequestID = logger.RequestID(tx.Statement.Context)
+	job.TraceContext = tracing.Inject(tx.Statement.Context)
+	if err := tx.Create(&job).Error; err != nil {
+		return err
+	}
+
+	return database.Notify(tx, model.JobsChannel, kind)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+)
+
+// ErrSourceRetracted is returned by Requeue for an image.process job whose
+// report was retracted, its photos are deleted instead of processed
+var ErrSourceRetracted = errors.New("report of the job is retracted")
+
+type JobRepository interface {
+	FindByStatus(ctx context.Context, status model.JobStatus, kind string, page int, limit int) ([]model.Job, int64, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Job, error)
+	Requeue(ctx context.Context, job *model.Job) (*model.Job, error)
+	Stats(ctx context.Context) (*model.JobStats, error)
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type JobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &JobRepositoryImpl{db: db}
+}
+
+func (r *JobRepositoryImpl) FindByStatus(
+	ctx context.Context,
+	status model.JobStatus,
+	kind string,
+	page int,
+	limit int,
+) ([]model.Job, int64, error) {
+
+	var (
+		jobs  []model.Job
+		total int64
+	)
+
+	offset := (page - 1) * limit
+
+	query := r.db.WithContext(ctx).
+		Model(&model.Job{}).
+		Where("status = ?", status)
+
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	err := query.Session(&gorm.Session{}).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// job yang terakhir berubah tampil duluan
+	err = query.Session(&gorm.Session{}).
+		Order("updated_at DESC, id").
+		Limit(limit).
+		Offset(offset).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+func (r *JobRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.Job, error) {
+	var job model.Job
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Requeue gives a dead-lettered job a fresh set of attempts
+func (r *JobRepositoryImpl) Requeue(ctx context.Context, job *model.Job) (*model.Job, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		job.Status = model.JobPending
+		job.Attempts = 0
+		job.LastError = ""
+		job.RunAt = time.Now()
+
+		err := tx.
+			Model(job).
+			Select("status", "attempts", "last_error", "run_at", "updated_at").
+			Updates(job).Error
+		if err != nil {
+			return err
+		}
+
+		if err := database.Notify(tx, model.JobsChannel, job.Kind); err != nil {
+			return err
+		}
+
+		// image job: row tetap "deleting" sampai worker selesai menghapus
+		var status model.ImageStatus
+		switch job.Kind {
+		case model.JobImageProcess:
+			status = model.Pending
+		case model.JobImageDelete:
+			status = model.Deleting
+		default:
+			return nil
+		}
+
+		var payload model.ImageJobPayload
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return err
+		}
+
+		query := tx.
+			Table(payload.SourceTable).
+			Where("id = ?", payload.SourceID)
+
+		// laporan yang sudah ditarik tidak diproses lagi
+		if job.Kind == model.JobImageProcess {
+			switch payload.SourceTable {
+			case model.SourceMissingPersons:
+				query = query.Where("deleted_at IS NULL")
+			case model.SourceSightings:
+				query = query.Where(`NOT EXISTS (
+					SELECT 1 FROM missing_persons m
+					WHERE m.id = sightings.missing_person_id
+					AND m.deleted_at IS NOT NULL
+				)`)
+			}
+		}
+
+		result := query.Update("image_status", status)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 && job.Kind == model.JobImageProcess {
+			return ErrSourceRetracted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (r *JobRepositoryImpl) Stats(ctx context.Context) (*model.JobStats, error) {
+	db := r.db.WithContext(ctx)
+	stats := &model.JobStats{
+		BusyByKind:    map[string]int64{},
+		ImageStatuses: map[model.ImageStatus]int64{},
+	}
+
+	var busy []struct {
+		Kind  string
+		Count int64
+	}
+	err := db.Model(&model.Job{}).
+		Select("kind, COUNT(*) AS count").
+		Where("status = ? AND lease_expires_at > NOW()", model.JobProcessing).
+		Group("kind").
+		Scan(&busy).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range busy {
+		stats.BusyByKind[row.Kind] = row.Count
+	}
+
+	// antrian dilihat dari sisi row, termasuk yang dead-letter
+	waiting := []model.ImageStatus{model.Pending, model.Processing, model.Deleting, model.Failed}
+	var depth []struct {
+		ImageStatus model.ImageStatus
+		Count       int64
+	}
+	err = db.Raw(`
+		SELECT image_status, COUNT(*) AS count FROM (
+			SELECT image_status FROM missing_persons WHERE image_status IN ?
+			UNION ALL
+			SELECT image_status FROM sightings WHERE image_status IN ?
+		) image_rows
+		GROUP BY image_status`, waiting, waiting).
+		Scan(&depth).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range depth {
+		stats.ImageStatuses[row.ImageStatus] = row.Count
+	}
+
+	var oldestPending sql.NullTime
+	err = db.Model(&model.Job{}).
+		Select("MIN(created_at)").
+		Where("status = ?", model.JobPending).
+		Row().Scan(&oldestPending)
+	if err != nil {
+		return nil, err
+	}
+	if oldestPending.Valid {
+		stats.OldestPendingAt = &oldestPending.Time
+	}
+
+	var lastSuccess sql.NullTime
+	err = db.Model(&model.Job{}).
+		Select("MAX(updated_at)").
+		Where("status = ?", model.JobDone).
+		Row().Scan(&lastSuccess)
+	if err != nil {
+		return nil, err
+	}
+	if lastSuccess.Valid {
+		stats.LastSuccessAt = &lastSuccess.Time
+	}
+
+	return stats, nil
+}
@@ -2,13 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Mhbib34/missing-person-service/internal/model"
 	"github.com/google/uuid"
 )
 
+// ErrImagesDeleted is returned by Restore when the photos of the report are
+// already being removed from storage
+var ErrImagesDeleted = errors.New("images of the report are already deleted")
+
+// MissingPersonRepository returns gorm.ErrRecordNotFound for unknown IDs,
+// the usecase decides how that is reported
 type MissingPersonRepository interface {
-	Create(ctx context.Context, missingPerson *model.MissingPersons)(*model.MissingPersons, error)
-	FindByID(ctx context.Context, id uuid.UUID)(*model.MissingPersons, error)
-	GetAll(ctx context.Context,page int, limit int) ([]model.MissingPersons, int64, error)
-}
\ No newline at end of file
+	Create(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.MissingPersons, error)
+	GetAll(ctx context.Context, filter model.MissingPersonFilter) ([]model.MissingPersons, int64, error)
+	GetModerationQueue(ctx context.Context, page int, limit int) ([]model.MissingPersons, int64, error)
+	FindNearby(ctx context.Context, latitude float64, longitude float64, radiusKm float64, limit int) ([]model.MissingPersonDistance, error)
+	Update(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error)
+	Moderate(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error)
+	Delete(ctx context.Context, missingPerson *model.MissingPersons) error
+	FindDeletedByID(ctx context.Context, id uuid.UUID) (*model.MissingPersons, error)
+	Restore(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error)
+}
@@ -2,35 +2,138 @@ package repository
 
 import (
 	"context"
+	"math"
 
-	"github.com/Mhbib34/missing-person-service/internal/exception"
 	"github.com/Mhbib34/missing-person-service/internal/model"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type MissingPersonRepositoryImpl struct{
+type MissingPersonRepositoryImpl struct {
 	db *gorm.DB
 }
 
 func NewMissingPersonRepository(db *gorm.DB) MissingPersonRepository {
-	return &MissingPersonRepositoryImpl{ db : db }
+	return &MissingPersonRepositoryImpl{db: db}
 }
 
 func (r *MissingPersonRepositoryImpl) Create(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error) {
-	err := r.db.WithContext(ctx).Create(missingPerson).Error
-	exception.PanicIfError(err)
+	// row dan job worker di-commit bersamaan
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(missingPerson).Error; err != nil {
+			return err
+		}
+
+		return enqueueImageJob(tx, model.JobImageProcess, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	})
+	if err != nil {
+		return nil, err
+	}
 	return missingPerson, nil
 }
 
 func (r *MissingPersonRepositoryImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.MissingPersons, error) {
 	var missingPerson model.MissingPersons
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&missingPerson).Error
-	exception.PanicIfError(err)
+	if err != nil {
+		return nil, err
+	}
 	return &missingPerson, nil
 }
 
+// sortColumns whitelists the columns clients may sort by
+var sortColumns = map[string]string{
+	"created_at": "created_at",
+	"name":       "name",
+	"age":        "age",
+}
+
 func (r *MissingPersonRepositoryImpl) GetAll(
+	ctx context.Context,
+	filter model.MissingPersonFilter,
+) ([]model.MissingPersons, int64, error) {
+
+	var (
+		missingPersons []model.MissingPersons
+		total          int64
+	)
+
+	offset := (filter.Page - 1) * filter.Limit
+
+	// found / closed reports are hidden unless explicitly requested
+	query := r.db.WithContext(ctx).
+		Model(&model.MissingPersons{}).
+		Where("image_status = ?", "ready")
+	if len(filter.CaseStatuses) > 0 {
+		query = query.Where("case_status IN ?", filter.CaseStatuses)
+	} else {
+		query = query.Where("case_status NOT IN ?", model.HiddenCaseStatuses)
+	}
+	if len(filter.ModerationStatuses) > 0 {
+		query = query.Where("moderation_status IN ?", filter.ModerationStatuses)
+	}
+
+	if filter.Query != "" {
+		query = query.Where("search_vector @@ websearch_to_tsquery('simple', ?)", filter.Query)
+	}
+	if filter.MinAge > 0 {
+		query = query.Where("age >= ?", filter.MinAge)
+	}
+	if filter.MaxAge > 0 {
+		query = query.Where("age <= ?", filter.MaxAge)
+	}
+	if filter.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		query = query.Where("created_at < ?", *filter.CreatedTo)
+	}
+
+	// hitung total data
+	err := query.Session(&gorm.Session{}).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// urutan data, id sebagai tie-breaker supaya paging stabil
+	var order clause.OrderBy
+	if column, ok := sortColumns[filter.SortBy]; ok {
+		order.Columns = append(order.Columns, clause.OrderByColumn{
+			Column: clause.Column{Name: column},
+			Desc:   filter.SortDesc,
+		}, clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+		})
+	} else if filter.SortBy == "relevance" && filter.Query != "" {
+		order.Expression = clause.Expr{
+			SQL:  "ts_rank(search_vector, websearch_to_tsquery('simple', ?)) DESC, created_at DESC, id",
+			Vars: []any{filter.Query},
+		}
+	} else {
+		order.Columns = append(order.Columns, clause.OrderByColumn{
+			Column: clause.Column{Name: "created_at"},
+			Desc:   true,
+		}, clause.OrderByColumn{
+			Column: clause.Column{Name: "id"},
+		})
+	}
+
+	// ambil data per page
+	err = query.Session(&gorm.Session{}).
+		Clauses(order).
+		Limit(filter.Limit).
+		Offset(offset).
+		Find(&missingPersons).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return missingPersons, total, nil
+}
+
+func (r *MissingPersonRepositoryImpl) GetModerationQueue(
 	ctx context.Context,
 	page int,
 	limit int,
@@ -43,22 +146,21 @@ func (r *MissingPersonRepositoryImpl) GetAll(
 
 	offset := (page - 1) * limit
 
-	// hitung total data
-	err := r.db.WithContext(ctx).
-		Where("image_status = ?", "ready").
+	query := r.db.WithContext(ctx).
 		Model(&model.MissingPersons{}).
+		Where("moderation_status = ?", model.PendingReview)
+
+	err := query.Session(&gorm.Session{}).
 		Count(&total).Error
-		
 	if err != nil {
 		return nil, 0, err
 	}
 
-	// ambil data per page
-	err = r.db.WithContext(ctx).
-		Where("image_status = ?", "ready").
+	// yang paling lama menunggu direview duluan
+	err = query.Session(&gorm.Session{}).
+		Order("created_at ASC, id").
 		Limit(limit).
 		Offset(offset).
-		Order("created_at DESC").
 		Find(&missingPersons).Error
 	if err != nil {
 		return nil, 0, err
@@ -66,3 +168,186 @@ func (r *MissingPersonRepositoryImpl) GetAll(
 
 	return missingPersons, total, nil
 }
+
+// earthRadiusKm is the mean earth radius used by the haversine formula
+const earthRadiusKm = 6371.0
+
+func (r *MissingPersonRepositoryImpl) FindNearby(
+	ctx context.Context,
+	latitude float64,
+	longitude float64,
+	radiusKm float64,
+	limit int,
+) ([]model.MissingPersonDistance, error) {
+
+	var missingPersons []model.MissingPersonDistance
+
+	// bounding box dulu supaya index (latitude, longitude) terpakai,
+	// baru dihitung jarak haversine yang sebenarnya
+	latDelta := radiusKm / 111.045
+	lngDelta := radiusKm / (111.045 * math.Max(math.Cos(latitude*math.Pi/180), 0.01))
+
+	distance := `? * 2 * asin(sqrt(
+		power(sin(radians(latitude - ?) / 2), 2) +
+		cos(radians(?)) * cos(radians(latitude)) * power(sin(radians(longitude - ?) / 2), 2)
+	))`
+
+	nearby := r.db.WithContext(ctx).
+		Model(&model.MissingPersons{}).
+		Select("missing_persons.*, "+distance+" AS distance_km", earthRadiusKm, latitude, latitude, longitude).
+		Where("image_status = ?", "ready").
+		Where("case_status NOT IN ?", model.HiddenCaseStatuses).
+		Where("moderation_status = ?", model.Approved).
+		Where("latitude BETWEEN ? AND ?", latitude-latDelta, latitude+latDelta).
+		Where("longitude BETWEEN ? AND ?", longitude-lngDelta, longitude+lngDelta)
+
+	err := r.db.WithContext(ctx).
+		Table("(?) AS nearby", nearby).
+		Where("distance_km <= ?", radiusKm).
+		Order("distance_km").
+		Limit(limit).
+		Find(&missingPersons).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return missingPersons, nil
+}
+
+func (r *MissingPersonRepositoryImpl) Update(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error) {
+	// only touch editable columns so a concurrent worker update of the
+	// image fields is not overwritten
+	err := r.db.WithContext(ctx).
+		Model(missingPerson).
+		Select(
+			"name", "age", "description", "last_seen",
+			"latitude", "longitude", "place_name", "city", "province",
+			"contact", "case_status", "moderation_status", "rejection_reason",
+			"moderated_by", "moderated_at", "updated_at",
+		).
+		Updates(missingPerson).Error
+	if err != nil {
+		return nil, err
+	}
+	return missingPerson, nil
+}
+
+func (r *MissingPersonRepositoryImpl) Moderate(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error) {
+	err := r.db.WithContext(ctx).
+		Model(missingPerson).
+		Select("moderation_status", "rejection_reason", "moderated_by", "moderated_at", "updated_at").
+		Updates(missingPerson).Error
+	if err != nil {
+		return nil, err
+	}
+	return missingPerson, nil
+}
+
+func (r *MissingPersonRepositoryImpl) Delete(ctx context.Context, missingPerson *model.MissingPersons) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// simpan siapa yang menarik laporan dan alasannya
+		err := tx.Model(missingPerson).
+			Updates(map[string]any{
+				"retracted_by":      missingPerson.RetractedBy,
+				"retraction_reason": missingPerson.RetractionReason,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Delete(missingPerson).Error; err != nil {
+			return err
+		}
+
+		// foto dihapus dari storage oleh worker
+		err = enqueueImageJob(tx, model.JobImageDelete, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+		if err != nil {
+			return err
+		}
+
+		// foto sighting ikut dihapus, URL-nya publik
+		var sightings []model.Sighting
+		err = tx.Select("id", "photo_id").
+			Where("missing_person_id = ? AND photo_id <> ''", missingPerson.ID).
+			Find(&sightings).Error
+		if err != nil {
+			return err
+		}
+		for _, sighting := range sightings {
+			err := enqueueImageJob(tx, model.JobImageDelete, model.SourceSightings, sighting.ID, sighting.PhotoID)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *MissingPersonRepositoryImpl) FindDeletedByID(ctx context.Context, id uuid.UUID) (*model.MissingPersons, error) {
+	var missingPerson model.MissingPersons
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		First(&missingPerson).Error
+	if err != nil {
+		return nil, err
+	}
+	return &missingPerson, nil
+}
+
+func (r *MissingPersonRepositoryImpl) Restore(ctx context.Context, missingPerson *model.MissingPersons) (*model.MissingPersons, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// delete job laporan dan sighting-nya
+		var sightingIDs []uuid.UUID
+		err := tx.Model(&model.Sighting{}).
+			Where("missing_person_id = ?", missingPerson.ID).
+			Pluck("id", &sightingIDs).Error
+		if err != nil {
+			return err
+		}
+		keys := []string{model.ImageJobKey(model.SourceMissingPersons, missingPerson.ID)}
+		for _, id := range sightingIDs {
+			keys = append(keys, model.ImageJobKey(model.SourceSightings, id))
+		}
+
+		// lock delete job supaya worker tidak mengambilnya selama restore
+		var deleteJobs []model.Job
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("kind = ? AND ordering_key IN ?", model.JobImageDelete, keys).
+			Find(&deleteJobs).Error
+		if err != nil {
+			return err
+		}
+
+		// foto yang sudah mulai dihapus tidak bisa dikembalikan
+		pending := make([]uuid.UUID, 0, len(deleteJobs))
+		for _, job := range deleteJobs {
+			if job.Status != model.JobPending {
+				return ErrImagesDeleted
+			}
+			pending = append(pending, job.ID)
+		}
+
+		// batalkan penghapusan foto yang belum diambil worker
+		if len(pending) > 0 {
+			if err := tx.Where("id IN ?", pending).Delete(&model.Job{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Unscoped().
+			Model(missingPerson).
+			Updates(map[string]any{
+				"deleted_at":  nil,
+				"restored_by": missingPerson.RestoredBy,
+				"restored_at": missingPerson.RestoredAt,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	missingPerson.DeletedAt = gorm.DeletedAt{}
+	return missingPerson, nil
+}
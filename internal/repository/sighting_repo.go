@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+)
+
+type SightingRepository interface {
+	Create(ctx context.Context, sighting *model.Sighting) (*model.Sighting, error)
+	FindByMissingPersonID(ctx context.Context, missingPersonID uuid.UUID, page int, limit int) ([]model.Sighting, int64, error)
+}
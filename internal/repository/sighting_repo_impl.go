@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SightingRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewSightingRepository(db *gorm.DB) SightingRepository {
+	return &SightingRepositoryImpl{db: db}
+}
+
+func (r *SightingRepositoryImpl) Create(ctx context.Context, sighting *model.Sighting) (*model.Sighting, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(sighting).Error; err != nil {
+			return err
+		}
+
+		// foto opsional, job hanya dibuat kalau ada foto
+		if sighting.PhotoID == "" {
+			return nil
+		}
+		return enqueueImageJob(tx, model.JobImageProcess, model.SourceSightings, sighting.ID, sighting.PhotoID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sighting, nil
+}
+
+func (r *SightingRepositoryImpl) FindByMissingPersonID(
+	ctx context.Context,
+	missingPersonID uuid.UUID,
+	page int,
+	limit int,
+) ([]model.Sighting, int64, error) {
+
+	var (
+		sightings []model.Sighting
+		total     int64
+	)
+
+	offset := (page - 1) * limit
+
+	query := r.db.WithContext(ctx).
+		Model(&model.Sighting{}).
+		Where("missing_person_id = ?", missingPersonID)
+
+	// hitung total data
+	err := query.Session(&gorm.Session{}).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// ambil data per page, penampakan terbaru dulu
+	err = query.Session(&gorm.Session{}).
+		Limit(limit).
+		Offset(offset).
+		Order("seen_at DESC").
+		Find(&sightings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return sightings, total, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+)
+
+// ErrEmailTaken is returned by Create when another account already uses the
+// email, also when it was registered after the usecase looked it up
+var ErrEmailTaken = errors.New("email already registered")
+
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) (*model.User, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdateRole(ctx context.Context, user *model.User) (*model.User, error)
+}
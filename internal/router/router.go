@@ -2,22 +2,74 @@ package router
 
 import (
 	"github.com/Mhbib34/missing-person-service/internal/controller"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
 	"github.com/Mhbib34/missing-person-service/internal/middleware"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func SetupRouter(controller controller.MissingPersonController) *gin.Engine {
+func SetupRouter(
+	controller controller.MissingPersonController,
+	sightingController controller.SightingController,
+	userController controller.UserController,
+	jobController controller.JobController,
+	healthController controller.HealthController,
+	imageStorage storage.ImageStorage,
+	uploader *upload.Uploader,
+	tokens *helper.TokenManager,
+) *gin.Engine {
 	r := gin.New()
 
 	// middleware
-	r.Use(gin.Logger())
+	r.Use(middleware.Tracing())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Logger())
+	r.Use(middleware.Metrics())
 	r.Use(middleware.ErrorRecovery()) // ⬅️ penting
 
-	api := r.Group("/api/v1")
+	// image dari local storage di-serve langsung oleh server ini
+	if local, ok := imageStorage.(*storage.LocalStorage); ok {
+		r.Static(storage.LocalRoutePath, local.Dir())
+	}
+
+	// dipakai orchestrator, tanpa auth
+	r.GET("/healthz", healthController.Liveness)
+	r.GET("/readyz", healthController.Readiness)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	api := r.Group("/api/v1", middleware.Authenticate(tokens))
 	{
-		api.POST("/missing-persons", controller.Create)
+		api.POST("/auth/register", userController.Register)
+		api.POST("/auth/login", userController.Login)
+
+		api.POST("/missing-persons", middleware.RequireRole(), middleware.LimitBodySize(uploader.MaxBodySize), controller.Create)
+		api.GET("/missing-persons/nearby", controller.FindNearby)
 		api.GET("/missing-persons/:id", controller.FindByID)
 		api.GET("/missing-persons", controller.GetAll)
+		api.PATCH("/missing-persons/:id", middleware.RequireRole(), controller.Update)
+		api.DELETE("/missing-persons/:id", middleware.RequireRole(), controller.Delete)
+
+		api.POST("/missing-persons/:id/sightings", middleware.LimitBodySize(uploader.MaxBodySize), sightingController.Create)
+		api.GET("/missing-persons/:id/sightings", sightingController.FindByMissingPersonID)
+	}
+
+	moderation := api.Group("/moderation", middleware.RequireRole(model.RoleModerator, model.RoleAdmin))
+	{
+		moderation.GET("/queue", controller.GetModerationQueue)
+		moderation.POST("/missing-persons/:id/approve", controller.Approve)
+		moderation.POST("/missing-persons/:id/reject", controller.Reject)
+	}
+
+	admin := api.Group("/admin", middleware.RequireRole(model.RoleAdmin))
+	{
+		admin.POST("/missing-persons/:id/restore", controller.Restore)
+		admin.PATCH("/users/:id/role", userController.UpdateRole)
+		admin.GET("/jobs", jobController.GetAll)
+		admin.POST("/jobs/:id/requeue", jobController.Requeue)
+		admin.GET("/worker/status", jobController.WorkerStatus)
 	}
 
 	return r
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// cloudinaryFolder prefixes every public_id
+const cloudinaryFolder = "missing-persons"
+
+type CloudinaryStorage struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewCloudinaryStorage(cfg config.CloudinaryConfig) (*CloudinaryStorage, error) {
+	cld, err := cloudinary.NewFromParams(
+		cfg.CloudName,
+		cfg.APIKey,
+		cfg.APISecret,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudinaryStorage{cld: cld}, nil
+}
+
+func (s *CloudinaryStorage) Upload(ctx context.Context, key string, filePath string) error {
+	_, err := s.cld.Upload.Upload(ctx, filePath, uploader.UploadParams{
+		PublicID:  key,
+		Folder:    cloudinaryFolder,
+		Overwrite: api.Bool(true),
+	})
+	return err
+}
+
+func (s *CloudinaryStorage) Delete(ctx context.Context, key string) error {
+	result, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID:   cloudinaryFolder + "/" + key,
+		Invalidate: api.Bool(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// "not found" berarti asset sudah tidak ada
+	if result.Error.Message != "" {
+		return errors.New(result.Error.Message)
+	}
+
+	return nil
+}
+
+func (s *CloudinaryStorage) Ping(ctx context.Context) error {
+	result, err := s.cld.Admin.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if result.Error.Message != "" {
+		return errors.New(result.Error.Message)
+	}
+	return nil
+}
+
+func (s *CloudinaryStorage) URL(key string) string {
+	image, err := s.cld.Image(cloudinaryFolder + "/" + key)
+	if err != nil {
+		return ""
+	}
+
+	url, err := image.String()
+	if err != nil {
+		return ""
+	}
+	return url
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage stores images in an S3-compatible bucket such as MinIO
+type S3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+func NewS3Storage(cfg config.S3Config) (*S3Storage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.CreateBucket {
+		if err := ensureBucket(context.Background(), client, cfg.Bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	return &S3Storage{
+		client:    client,
+		bucket:    cfg.Bucket,
+		publicURL: strings.TrimRight(cfg.PublicURL, "/"),
+	}, nil
+}
+
+func (s *S3Storage) Upload(ctx context.Context, key string, filePath string) error {
+	contentType, err := detectContentType(filePath)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.FPutObject(ctx, s.bucket, key, filePath, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	// menghapus object yang tidak ada bukan error di S3
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) Ping(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.bucket)
+	}
+	return nil
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// ensureBucket creates the bucket with public read access when it does not
+// exist yet, so a fresh MinIO works without manual setup. An existing
+// bucket is left as it is, its policy is never touched.
+func ensureBucket(ctx context.Context, client *minio.Client, bucket string) error {
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil || exists {
+		return err
+	}
+
+	if err := client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{}); err != nil {
+		return err
+	}
+
+	policy := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::` + bucket + `/*"]}]}`
+	return client.SetBucketPolicy(ctx, bucket, policy)
+}
+
+// detectContentType sniffs the first bytes of the file since keys have no
+// extension
+func detectContentType(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buf := make([]byte, 512)
+	n, err := file.Read(buf)
+	if err != nil && n == 0 {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+)
+
+// ImageStorage stores processed images under a key such as the report ID.
+// Keys never carry a file extension; backends detect the content type.
+type ImageStorage interface {
+	Upload(ctx context.Context, key string, filePath string) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+
+	// Ping reports whether the backend is reachable
+	Ping(ctx context.Context) error
+}
+
+// NewImageStorage returns the backend selected by the storage driver
+// (cloudinary, local or s3). Cloudinary is the default.
+func NewImageStorage(cfg *config.Config) (ImageStorage, error) {
+	switch cfg.Storage.Driver {
+	case "cloudinary":
+		return NewCloudinaryStorage(cfg.Storage.Cloudinary)
+	case "local":
+		return NewLocalStorage(cfg.Storage.Local.Dir, cfg.Storage.Local.URL)
+	case "s3":
+		return NewS3Storage(cfg.Storage.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+	}
+}
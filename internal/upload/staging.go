@@ -0,0 +1,46 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Staging is the directory uploads wait in until the worker has processed
+// them. The API writes to it and the worker reads from it, so when they run
+// as separate processes it must be storage both can reach, e.g. a volume
+// mounted in every container.
+type Staging struct {
+	dir string
+}
+
+func NewStaging(dir string) (*Staging, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &Staging{dir: dir}, nil
+}
+
+// Dir is the staging directory
+func (s *Staging) Dir() string {
+	return s.dir
+}
+
+// Path is where the staged photo is stored
+func (s *Staging) Path(photoID string) string {
+	return filepath.Join(s.dir, photoID)
+}
+
+// Ping reports whether the staging directory can be reached
+func (s *Staging) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", s.dir)
+	}
+	return nil
+}
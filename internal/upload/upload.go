@@ -0,0 +1,153 @@
+package upload
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"os"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// extra room for the text fields sent along with the photo
+	formOverhead = 1 << 20
+
+	minDimension = 64
+	maxDimension = 10000
+	maxPixels    = 50_000_000
+)
+
+// imageType is an accepted upload format recognised by its magic bytes
+type imageType struct {
+	mime string
+	ext  string
+}
+
+var (
+	jpegType = imageType{mime: "image/jpeg", ext: ".jpg"}
+	pngType  = imageType{mime: "image/png", ext: ".png"}
+	webpType = imageType{mime: "image/webp", ext: ".webp"}
+)
+
+// Uploader checks and stages uploaded photos
+type Uploader struct {
+	maxSize int64
+	staging *Staging
+}
+
+func NewUploader(cfg *config.Config, staging *Staging) *Uploader {
+	return &Uploader{maxSize: int64(cfg.Upload.MaxSizeMB) << 20, staging: staging}
+}
+
+// MaxSize is the largest accepted photo in bytes
+func (u *Uploader) MaxSize() int64 {
+	return u.maxSize
+}
+
+// MaxBodySize is the largest accepted multipart request body
+func (u *Uploader) MaxBodySize() int64 {
+	return u.maxSize + formOverhead
+}
+
+// SaveImage checks the uploaded photo and stages it under a server
+// generated name, which is returned. The client's filename is never used.
+func (u *Uploader) SaveImage(file *multipart.FileHeader) (string, error) {
+	maxSize := u.maxSize
+	if file.Size > maxSize {
+		return "", exception.NewPayloadTooLargeError(fmt.Sprintf("photo must not be larger than %d MB", maxSize>>20))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	// header cukup untuk magic bytes dan ukuran gambar
+	header := make([]byte, 256<<10)
+	n, err := io.ReadFull(src, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", exception.NewBadRequestError("photo could not be read")
+	}
+	header = header[:n]
+
+	// worker belum bisa decode HEIC, ditolak sampai ada decoder
+	if isHEIC(header) {
+		return "", exception.NewBadRequestError("HEIC photos are not supported yet, upload a JPEG, PNG or WebP image")
+	}
+
+	kind, ok := sniff(header)
+	if !ok {
+		return "", exception.NewBadRequestError("photo must be a JPEG, PNG or WebP image")
+	}
+
+	if err := checkDimensions(header); err != nil {
+		return "", err
+	}
+
+	filename := uuid.NewString() + kind.ext
+	dst, err := os.OpenFile(u.staging.Path(filename), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return "", err
+	}
+
+	// header sudah terbaca, sisanya disalin langsung
+	_, err = io.Copy(dst, io.MultiReader(bytes.NewReader(header), src))
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dst.Name())
+		return "", err
+	}
+
+	return filename, nil
+}
+
+func sniff(header []byte) (imageType, bool) {
+	switch {
+	case bytes.HasPrefix(header, []byte{0xFF, 0xD8, 0xFF}):
+		return jpegType, true
+	case bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n")):
+		return pngType, true
+	case len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == "WEBP":
+		return webpType, true
+	}
+	return imageType{}, false
+}
+
+// isHEIC recognises a HEIC/HEIF photo by the brand of its ftyp box
+func isHEIC(header []byte) bool {
+	if len(header) < 12 || string(header[4:8]) != "ftyp" {
+		return false
+	}
+	switch string(header[8:12]) {
+	case "heic", "heix", "hevc", "hevx", "heim", "heis", "mif1", "msf1":
+		return true
+	}
+	return false
+}
+
+func checkDimensions(header []byte) error {
+	config, _, err := image.DecodeConfig(bytes.NewReader(header))
+	if err != nil {
+		return exception.NewBadRequestError("photo dimensions could not be read")
+	}
+	width, height := config.Width, config.Height
+
+	if width < minDimension || height < minDimension {
+		return exception.NewBadRequestError(fmt.Sprintf("photo must be at least %dx%d pixels", minDimension, minDimension))
+	}
+	if width > maxDimension || height > maxDimension || width*height > maxPixels {
+		return exception.NewBadRequestError(fmt.Sprintf("photo must not be larger than %dx%d pixels", maxDimension, maxDimension))
+	}
+	return nil
+}
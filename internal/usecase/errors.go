@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"gorm.io/gorm"
+)
+
+// notFound reports a record the repository could not find as NotFoundError
+// with message, other errors are returned as they are
+func notFound(err error, message string) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return exception.WrapNotFoundError(err, message)
+	}
+	return err
+}
+
+// invalid wraps the error of validator.Struct as ValidationError
+func invalid(err error) error {
+	return exception.WrapValidationError(err, "request validation failed")
+}
@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+)
+
+type HealthUsecase interface {
+	Readiness(ctx context.Context) (dto.ReadinessResponse, error)
+}
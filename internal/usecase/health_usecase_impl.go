@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
+	"gorm.io/gorm"
+)
+
+// checkTimeout bounds every readiness check, a hanging dependency counts as
+// down
+const checkTimeout = 2 * time.Second
+
+type HealthUsecaseImpl struct {
+	db       *gorm.DB
+	storage  storage.ImageStorage
+	staging  *upload.Staging
+	migrator *migration.Migrator
+}
+
+func NewHealthUsecase(db *gorm.DB, imageStorage storage.ImageStorage, staging *upload.Staging, migrator *migration.Migrator) HealthUsecase {
+	return &HealthUsecaseImpl{
+		db:       db,
+		storage:  imageStorage,
+		staging:  staging,
+		migrator: migrator,
+	}
+}
+
+// Readiness checks every dependency needed to serve requests. A failing
+// check is part of the response, not an error; the reason is only logged
+// since the endpoint is public.
+func (service *HealthUsecaseImpl) Readiness(ctx context.Context) (dto.ReadinessResponse, error) {
+	checks := map[string]func(ctx context.Context) error{
+		"database": func(ctx context.Context) error {
+			sqlDB, err := service.db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		},
+		"storage":    service.storage.Ping,
+		"staging":    service.staging.Ping,
+		"migrations": service.migrator.Check,
+	}
+
+	response := dto.ReadinessResponse{Ready: true, Checks: map[string]string{}}
+	for name, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+		err := check(checkCtx)
+		cancel()
+
+		if err != nil {
+			slog.WarnContext(ctx, "readiness check failed", "check", name, "error", err)
+			response.Ready = false
+			response.Checks[name] = "fail"
+			continue
+		}
+		response.Checks[name] = "ok"
+	}
+
+	return response, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/google/uuid"
+)
+
+type JobUsecase interface {
+	GetAll(ctx context.Context, status string, kind string, page int, limit int) ([]dto.JobResponse, int64, error)
+	Requeue(ctx context.Context, id uuid.UUID) (dto.JobResponse, error)
+	WorkerStatus(ctx context.Context) (dto.WorkerStatusResponse, error)
+}
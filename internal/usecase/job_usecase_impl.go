@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/google/uuid"
+)
+
+type JobUsecaseImpl struct {
+	repository repository.JobRepository
+}
+
+func NewJobUsecase(repository repository.JobRepository) JobUsecase {
+	return &JobUsecaseImpl{repository: repository}
+}
+
+var jobStatuses = map[model.JobStatus]bool{
+	model.JobPending:    true,
+	model.JobProcessing: true,
+	model.JobDone:       true,
+	model.JobFailed:     true,
+}
+
+// GetAll lists jobs by status, dead-lettered jobs by default
+func (service *JobUsecaseImpl) GetAll(ctx context.Context, status string, kind string, page int, limit int) ([]dto.JobResponse, int64, error) {
+	jobStatus := model.JobFailed
+	if status != "" {
+		jobStatus = model.JobStatus(status)
+	}
+	if !jobStatuses[jobStatus] {
+		return nil, 0, exception.NewBadRequestError("invalid job status: " + status)
+	}
+
+	jobs, total, err := service.repository.FindByStatus(ctx, jobStatus, kind, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return helper.ToJobResponses(jobs), total, nil
+}
+
+func (service *JobUsecaseImpl) Requeue(ctx context.Context, id uuid.UUID) (dto.JobResponse, error) {
+	job, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return dto.JobResponse{}, notFound(err, "Job not found")
+	}
+
+	// hanya job dead-letter yang boleh diantrikan ulang
+	if job.Status != model.JobFailed {
+		return dto.JobResponse{}, exception.NewConflictError("only failed jobs can be requeued")
+	}
+
+	job, err = service.repository.Requeue(ctx, job)
+	if errors.Is(err, repository.ErrSourceRetracted) {
+		return dto.JobResponse{}, exception.WrapConflictError(err, "the report of this job was retracted, its photos are not processed again")
+	}
+	if err != nil {
+		return dto.JobResponse{}, err
+	}
+
+	return helper.ToJobResponse(*job), nil
+}
+
+func (service *JobUsecaseImpl) WorkerStatus(ctx context.Context) (dto.WorkerStatusResponse, error) {
+	stats, err := service.repository.Stats(ctx)
+	if err != nil {
+		return dto.WorkerStatusResponse{}, err
+	}
+
+	return helper.ToWorkerStatusResponse(*stats, time.Now()), nil
+}
@@ -9,7 +9,14 @@ import (
 )
 
 type MissingPersonUsecase interface {
-	Create(ctx context.Context, request dto.CreateMissingPersonRequest)(dto.MissingPersonResponse, error)
-	FindByID(ctx context.Context, id uuid.UUID)(*model.MissingPersons, error)
-	GetAll(ctx context.Context, page int, limit int)([]model.MissingPersons, int64, error)
-}
\ No newline at end of file
+	Create(ctx context.Context, principal model.Principal, request dto.CreateMissingPersonRequest) (dto.MissingPersonResponse, error)
+	FindByID(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error)
+	GetAll(ctx context.Context, principal model.Principal, request dto.SearchMissingPersonRequest) ([]dto.MissingPersonResponse, int64, error)
+	GetModerationQueue(ctx context.Context, page int, limit int) ([]dto.MissingPersonResponse, int64, error)
+	Approve(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error)
+	Reject(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.RejectMissingPersonRequest) (dto.MissingPersonResponse, error)
+	FindNearby(ctx context.Context, request dto.NearbyMissingPersonRequest) ([]dto.NearbyMissingPersonResponse, error)
+	Update(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.UpdateMissingPersonRequest) (dto.MissingPersonResponse, error)
+	Delete(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.DeleteMissingPersonRequest) error
+	Restore(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error)
+}
@@ -2,54 +2,357 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Mhbib34/missing-person-service/internal/dto"
 	"github.com/Mhbib34/missing-person-service/internal/exception"
 	"github.com/Mhbib34/missing-person-service/internal/helper"
 	"github.com/Mhbib34/missing-person-service/internal/model"
 	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
 type MissingPersonUsecaseImpl struct {
 	repository repository.MissingPersonRepository
-	Validate       *validator.Validate
+	Validate   *validator.Validate
+	uploader   *upload.Uploader
 }
 
-func NewMissingPersonUsecase(repository repository.MissingPersonRepository, validate *validator.Validate) MissingPersonUsecase {
-	return &MissingPersonUsecaseImpl{repository: repository, Validate: validate}
+func NewMissingPersonUsecase(repository repository.MissingPersonRepository, validate *validator.Validate, uploader *upload.Uploader) MissingPersonUsecase {
+	return &tracedMissingPersonUsecase{next: &MissingPersonUsecaseImpl{repository: repository, Validate: validate, uploader: uploader}}
 }
 
-func (service *MissingPersonUsecaseImpl) Create(ctx context.Context, request dto.CreateMissingPersonRequest) (dto.MissingPersonResponse, error) {
+func (service *MissingPersonUsecaseImpl) Create(ctx context.Context, principal model.Principal, request dto.CreateMissingPersonRequest) (dto.MissingPersonResponse, error) {
 	err := service.Validate.Struct(request)
-	exception.PanicIfError(err)
+	if err != nil {
+		return dto.MissingPersonResponse{}, invalid(err)
+	}
+
+	// foto dicek dan disimpan dengan nama dari server
+	photoID, err := service.uploader.SaveImage(request.Photo)
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
 
 	missingPerson := &model.MissingPersons{
-		Name: request.Name, 
-		Age: request.Age, 
-		Description: request.Description,  
-		LastSeen: request.LastSeen, 
-		Contact: request.Contact, 
-		PhotoID: request.Photo.Filename,
-	}
-	
+		Name:             request.Name,
+		Age:              request.Age,
+		Description:      request.Description,
+		LastSeen:         request.LastSeen,
+		Latitude:         request.Latitude,
+		Longitude:        request.Longitude,
+		PlaceName:        request.PlaceName,
+		City:             request.City,
+		Province:         request.Province,
+		Contact:          request.Contact,
+		PhotoID:          photoID,
+		ReporterID:       &principal.UserID,
+		ModerationStatus: model.PendingReview,
+	}
+
 	missingPerson, err = service.repository.Create(ctx, missingPerson)
-	exception.PanicIfError(err)
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
+
+	return helper.ToMissingPersonResponse(*missingPerson), nil
+}
+
+func (service *MissingPersonUsecaseImpl) FindByID(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
+	missingPerson, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return dto.MissingPersonResponse{}, notFound(err, "Report not found")
+	}
+
+	// laporan yang belum di-approve tidak terlihat oleh publik
+	if !principal.CanView(*missingPerson) {
+		return dto.MissingPersonResponse{}, exception.NewNotFoundError("Report not found")
+	}
+
+	return responseFor(principal, *missingPerson), nil
+}
+
+// responseFor hides who reported the report and why it was rejected from
+// anyone but the reporter and moderators
+func responseFor(principal model.Principal, missingPerson model.MissingPersons) dto.MissingPersonResponse {
+	response := helper.ToMissingPersonResponse(missingPerson)
+	if !principal.Owns(missingPerson) && !principal.IsModerator() {
+		response.ReporterID = ""
+		response.RejectionReason = ""
+	}
+	return response
+}
+
+func (service *MissingPersonUsecaseImpl) GetAll(ctx context.Context, principal model.Principal, request dto.SearchMissingPersonRequest) ([]dto.MissingPersonResponse, int64, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return nil, 0, invalid(err)
+	}
+
+	filter := model.MissingPersonFilter{
+		Page:     request.Page,
+		Limit:    request.Limit,
+		Query:    strings.TrimSpace(request.Query),
+		MinAge:   request.MinAge,
+		MaxAge:   request.MaxAge,
+		SortBy:   request.Sort,
+		SortDesc: request.Order == "desc",
+	}
+
+	for _, status := range request.CaseStatuses {
+		filter.CaseStatuses = append(filter.CaseStatuses, model.CaseStatus(status))
+	}
+
+	// moderator melihat semua laporan, publik hanya yang sudah di-approve
+	if !principal.IsModerator() {
+		filter.ModerationStatuses = []model.ModerationStatus{model.Approved}
+	}
+
+	// tanggal sudah divalidasi format-nya, created_to inklusif sampai akhir hari
+	if request.CreatedFrom != "" {
+		from, _ := time.Parse(time.DateOnly, request.CreatedFrom)
+		filter.CreatedFrom = &from
+	}
+	if request.CreatedTo != "" {
+		to, _ := time.Parse(time.DateOnly, request.CreatedTo)
+		to = to.AddDate(0, 0, 1)
+		filter.CreatedTo = &to
+	}
+
+	missingPersons, total, err := service.repository.GetAll(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := make([]dto.MissingPersonResponse, 0, len(missingPersons))
+	for _, missingPerson := range missingPersons {
+		responses = append(responses, responseFor(principal, missingPerson))
+	}
+
+	return responses, total, nil
+}
+
+func (service *MissingPersonUsecaseImpl) GetModerationQueue(ctx context.Context, page int, limit int) ([]dto.MissingPersonResponse, int64, error) {
+	missingPersons, total, err := service.repository.GetModerationQueue(ctx, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	return helper.ToMissingPersonResponse(*missingPerson), err
+	return helper.ToMissingPersonResponses(missingPersons), total, nil
 }
 
-func (service *MissingPersonUsecaseImpl) FindByID(ctx context.Context, id uuid.UUID) (*model.MissingPersons, error) {
+func (service *MissingPersonUsecaseImpl) Approve(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
 	missingPerson, err := service.repository.FindByID(ctx, id)
-	exception.PanicIfError(err)
-	
-	return missingPerson, nil
+	if err != nil {
+		return dto.MissingPersonResponse{}, notFound(err, "Report not found")
+	}
+
+	if missingPerson.ModerationStatus == model.Approved {
+		return dto.MissingPersonResponse{}, exception.NewConflictError("report is already approved")
+	}
+
+	now := time.Now()
+	missingPerson.ModerationStatus = model.Approved
+	missingPerson.RejectionReason = ""
+	missingPerson.ModeratedBy = &principal.UserID
+	missingPerson.ModeratedAt = &now
+
+	missingPerson, err = service.repository.Moderate(ctx, missingPerson)
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
+
+	return helper.ToMissingPersonResponse(*missingPerson), nil
 }
 
-func (service *MissingPersonUsecaseImpl) GetAll(ctx context.Context, page int, limit int) ([]model.MissingPersons, int64, error) {
-	missingPersons, total, err := service.repository.GetAll(ctx, page, limit)
-	exception.PanicIfError(err)
-	
-	return missingPersons, total, nil
-}
\ No newline at end of file
+func (service *MissingPersonUsecaseImpl) Reject(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.RejectMissingPersonRequest) (dto.MissingPersonResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.MissingPersonResponse{}, invalid(err)
+	}
+
+	missingPerson, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return dto.MissingPersonResponse{}, notFound(err, "Report not found")
+	}
+
+	if missingPerson.ModerationStatus == model.Rejected {
+		return dto.MissingPersonResponse{}, exception.NewConflictError("report is already rejected")
+	}
+
+	now := time.Now()
+	missingPerson.ModerationStatus = model.Rejected
+	missingPerson.RejectionReason = request.Reason
+	missingPerson.ModeratedBy = &principal.UserID
+	missingPerson.ModeratedAt = &now
+
+	missingPerson, err = service.repository.Moderate(ctx, missingPerson)
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
+
+	return helper.ToMissingPersonResponse(*missingPerson), nil
+}
+
+func (service *MissingPersonUsecaseImpl) FindNearby(ctx context.Context, request dto.NearbyMissingPersonRequest) ([]dto.NearbyMissingPersonResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return nil, invalid(err)
+	}
+
+	missingPersons, err := service.repository.FindNearby(
+		ctx,
+		*request.Latitude,
+		*request.Longitude,
+		request.RadiusKm,
+		request.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// pencarian sekitar selalu publik
+	responses := make([]dto.NearbyMissingPersonResponse, 0, len(missingPersons))
+	for _, missingPerson := range missingPersons {
+		responses = append(responses, dto.NearbyMissingPersonResponse{
+			MissingPersonResponse: responseFor(model.Principal{}, missingPerson.MissingPersons),
+			DistanceKm:            missingPerson.DistanceKm,
+		})
+	}
+
+	return responses, nil
+}
+
+func (service *MissingPersonUsecaseImpl) Update(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.UpdateMissingPersonRequest) (dto.MissingPersonResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.MissingPersonResponse{}, invalid(err)
+	}
+
+	missingPerson, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return dto.MissingPersonResponse{}, notFound(err, "Report not found")
+	}
+
+	// hanya pelapor atau moderator yang boleh mengubah laporan
+	if !principal.Owns(*missingPerson) && !principal.IsModerator() {
+		return dto.MissingPersonResponse{}, exception.NewForbiddenError("you can only edit your own reports")
+	}
+
+	// edited menandai perubahan isi laporan, bukan status kasus
+	edited := false
+	if request.Name != nil {
+		missingPerson.Name = *request.Name
+		edited = true
+	}
+	if request.Age != nil {
+		missingPerson.Age = *request.Age
+		edited = true
+	}
+	if request.Description != nil {
+		missingPerson.Description = *request.Description
+		edited = true
+	}
+	if request.LastSeen != nil {
+		missingPerson.LastSeen = *request.LastSeen
+		edited = true
+	}
+	if request.Latitude != nil && request.Longitude != nil {
+		missingPerson.Latitude = request.Latitude
+		missingPerson.Longitude = request.Longitude
+		edited = true
+	}
+	if request.PlaceName != nil {
+		missingPerson.PlaceName = *request.PlaceName
+		edited = true
+	}
+	if request.City != nil {
+		missingPerson.City = *request.City
+		edited = true
+	}
+	if request.Province != nil {
+		missingPerson.Province = *request.Province
+		edited = true
+	}
+	if request.Contact != nil {
+		missingPerson.Contact = *request.Contact
+		edited = true
+	}
+
+	// isi yang diubah pelapor harus direview lagi, baik laporan yang ditolak
+	// maupun yang sudah disetujui
+	if edited && !principal.IsModerator() && missingPerson.ModerationStatus != model.PendingReview {
+		missingPerson.ModerationStatus = model.PendingReview
+		missingPerson.RejectionReason = ""
+		missingPerson.ModeratedBy = nil
+		missingPerson.ModeratedAt = nil
+	}
+
+	// validasi perubahan status kasus
+	if request.CaseStatus != nil {
+		next := model.CaseStatus(*request.CaseStatus)
+		if next != missingPerson.CaseStatus {
+			if !missingPerson.CaseStatus.CanTransitionTo(next) {
+				return dto.MissingPersonResponse{}, exception.NewConflictError(fmt.Sprintf(
+					"cannot change case status from %s to %s", missingPerson.CaseStatus, next,
+				))
+			}
+			missingPerson.CaseStatus = next
+		}
+	}
+
+	missingPerson, err = service.repository.Update(ctx, missingPerson)
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
+
+	return helper.ToMissingPersonResponse(*missingPerson), nil
+}
+
+func (service *MissingPersonUsecaseImpl) Delete(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.DeleteMissingPersonRequest) error {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return invalid(err)
+	}
+
+	missingPerson, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return notFound(err, "Report not found")
+	}
+
+	if !principal.Owns(*missingPerson) && !principal.IsModerator() {
+		return exception.NewForbiddenError("you can only retract your own reports")
+	}
+
+	missingPerson.RetractedBy = &principal.UserID
+	missingPerson.RetractionReason = request.Reason
+
+	// image di storage dihapus oleh worker setelah row di-soft delete
+	return service.repository.Delete(ctx, missingPerson)
+}
+
+func (service *MissingPersonUsecaseImpl) Restore(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
+	missingPerson, err := service.repository.FindDeletedByID(ctx, id)
+	if err != nil {
+		return dto.MissingPersonResponse{}, notFound(err, "Report not found")
+	}
+
+	now := time.Now()
+	missingPerson.RestoredBy = &principal.UserID
+	missingPerson.RestoredAt = &now
+
+	missingPerson, err = service.repository.Restore(ctx, missingPerson)
+	if errors.Is(err, repository.ErrImagesDeleted) {
+		return dto.MissingPersonResponse{}, exception.WrapConflictError(err, "photos of this report are already deleted, it cannot be restored")
+	}
+	if err != nil {
+		return dto.MissingPersonResponse{}, err
+	}
+
+	return helper.ToMissingPersonResponse(*missingPerson), nil
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedMissingPersonUsecase runs every method of the usecase in its own
+// span and records the returned error on it
+type tracedMissingPersonUsecase struct {
+	next MissingPersonUsecase
+}
+
+func idAttr(id uuid.UUID) attribute.KeyValue {
+	return attribute.String("missing_person.id", id.String())
+}
+
+func (t *tracedMissingPersonUsecase) Create(ctx context.Context, principal model.Principal, request dto.CreateMissingPersonRequest) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Create")
+	result, err := t.next.Create(ctx, principal, request)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) FindByID(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.FindByID", idAttr(id))
+	result, err := t.next.FindByID(ctx, principal, id)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) GetAll(ctx context.Context, principal model.Principal, request dto.SearchMissingPersonRequest) ([]dto.MissingPersonResponse, int64, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.GetAll")
+	result, total, err := t.next.GetAll(ctx, principal, request)
+	tracing.Finish(span, err)
+	return result, total, err
+}
+
+func (t *tracedMissingPersonUsecase) GetModerationQueue(ctx context.Context, page int, limit int) ([]dto.MissingPersonResponse, int64, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.GetModerationQueue")
+	result, total, err := t.next.GetModerationQueue(ctx, page, limit)
+	tracing.Finish(span, err)
+	return result, total, err
+}
+
+func (t *tracedMissingPersonUsecase) Approve(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Approve", idAttr(id))
+	result, err := t.next.Approve(ctx, principal, id)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) Reject(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.RejectMissingPersonRequest) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Reject", idAttr(id))
+	result, err := t.next.Reject(ctx, principal, id, request)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) FindNearby(ctx context.Context, request dto.NearbyMissingPersonRequest) ([]dto.NearbyMissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.FindNearby")
+	result, err := t.next.FindNearby(ctx, request)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) Update(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.UpdateMissingPersonRequest) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Update", idAttr(id))
+	result, err := t.next.Update(ctx, principal, id, request)
+	tracing.Finish(span, err)
+	return result, err
+}
+
+func (t *tracedMissingPersonUsecase) Delete(ctx context.Context, principal model.Principal, id uuid.UUID, request dto.DeleteMissingPersonRequest) error {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Delete", idAttr(id))
+	err := t.next.Delete(ctx, principal, id, request)
+	tracing.Finish(span, err)
+	return err
+}
+
+func (t *tracedMissingPersonUsecase) Restore(ctx context.Context, principal model.Principal, id uuid.UUID) (dto.MissingPersonResponse, error) {
+	ctx, span := tracing.Start(ctx, "MissingPersonUsecase.Restore", idAttr(id))
+	result, err := t.next.Restore(ctx, principal, id)
+	tracing.Finish(span, err)
+	return result, err
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+)
+
+type SightingUsecase interface {
+	Create(ctx context.Context, missingPersonID uuid.UUID, request dto.CreateSightingRequest) (dto.SightingResponse, error)
+
+	// FindByMissingPersonID leaves the witness details empty unless the
+	// principal owns the report or is a moderator
+	FindByMissingPersonID(ctx context.Context, principal model.Principal, missingPersonID uuid.UUID, page int, limit int) ([]dto.SightingResponse, int64, error)
+}
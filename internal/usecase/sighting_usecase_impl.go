@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type SightingUsecaseImpl struct {
+	repository              repository.SightingRepository
+	missingPersonRepository repository.MissingPersonRepository
+	Validate                *validator.Validate
+	uploader                *upload.Uploader
+}
+
+func NewSightingUsecase(
+	repository repository.SightingRepository,
+	missingPersonRepository repository.MissingPersonRepository,
+	validate *validator.Validate,
+	uploader *upload.Uploader,
+) SightingUsecase {
+	return &SightingUsecaseImpl{
+		repository:              repository,
+		missingPersonRepository: missingPersonRepository,
+		Validate:                validate,
+		uploader:                uploader,
+	}
+}
+
+func (service *SightingUsecaseImpl) Create(ctx context.Context, missingPersonID uuid.UUID, request dto.CreateSightingRequest) (dto.SightingResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.SightingResponse{}, invalid(err)
+	}
+
+	// pastikan laporan orang hilang ada dan sudah dipublikasikan
+	missingPerson, err := service.missingPersonRepository.FindByID(ctx, missingPersonID)
+	if err != nil {
+		return dto.SightingResponse{}, notFound(err, "Report not found")
+	}
+
+	if missingPerson.ModerationStatus != model.Approved {
+		return dto.SightingResponse{}, exception.NewNotFoundError("Report not found")
+	}
+
+	sighting := &model.Sighting{
+		MissingPersonID: missingPersonID,
+		SeenAt:          request.SeenAt,
+		Location:        request.Location,
+		Latitude:        request.Latitude,
+		Longitude:       request.Longitude,
+		Description:     request.Description,
+		ReporterName:    request.ReporterName,
+		ReporterContact: request.ReporterContact,
+	}
+
+	// foto opsional, diproses worker yang sama dengan foto laporan
+	if request.Photo != nil {
+		sighting.PhotoID, err = service.uploader.SaveImage(request.Photo)
+		if err != nil {
+			return dto.SightingResponse{}, err
+		}
+		sighting.ImageStatus = model.Pending
+	}
+
+	sighting, err = service.repository.Create(ctx, sighting)
+	if err != nil {
+		return dto.SightingResponse{}, err
+	}
+
+	return helper.ToSightingResponse(*sighting), nil
+}
+
+func (service *SightingUsecaseImpl) FindByMissingPersonID(ctx context.Context, principal model.Principal, missingPersonID uuid.UUID, page int, limit int) ([]dto.SightingResponse, int64, error) {
+	missingPerson, err := service.missingPersonRepository.FindByID(ctx, missingPersonID)
+	if err != nil {
+		return nil, 0, notFound(err, "Report not found")
+	}
+
+	if missingPerson.ModerationStatus != model.Approved {
+		return nil, 0, exception.NewNotFoundError("Report not found")
+	}
+
+	sightings, total, err := service.repository.FindByMissingPersonID(ctx, missingPersonID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	responses := helper.ToSightingResponses(sightings)
+
+	// nama dan kontak saksi hanya untuk pemilik laporan dan moderator
+	if !principal.CanContactWitnesses(*missingPerson) {
+		for i := range responses {
+			responses[i].ReporterName = ""
+			responses[i].ReporterContact = ""
+		}
+	}
+	return responses, total, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/google/uuid"
+)
+
+type UserUsecase interface {
+	Register(ctx context.Context, request dto.RegisterRequest) (dto.UserResponse, error)
+	Login(ctx context.Context, request dto.LoginRequest) (dto.LoginResponse, error)
+	UpdateRole(ctx context.Context, id uuid.UUID, request dto.UpdateUserRoleRequest) (dto.UserResponse, error)
+}
@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+type UserUsecaseImpl struct {
+	repository repository.UserRepository
+	Validate   *validator.Validate
+	tokens     *helper.TokenManager
+}
+
+func NewUserUsecase(repository repository.UserRepository, validate *validator.Validate, tokens *helper.TokenManager) UserUsecase {
+	return &UserUsecaseImpl{repository: repository, Validate: validate, tokens: tokens}
+}
+
+func (service *UserUsecaseImpl) Register(ctx context.Context, request dto.RegisterRequest) (dto.UserResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.UserResponse{}, invalid(err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+
+	_, err = service.repository.FindByEmail(ctx, email)
+	if err == nil {
+		return dto.UserResponse{}, exception.NewConflictError("email already registered")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.UserResponse{}, err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	// akun baru selalu reporter, role lain diberikan oleh admin
+	user := &model.User{
+		Name:         request.Name,
+		Email:        email,
+		PasswordHash: string(hash),
+		Role:         model.RoleReporter,
+	}
+
+	// register bersamaan dengan email yang sama lolos dari cek di atas
+	user, err = service.repository.Create(ctx, user)
+	if errors.Is(err, repository.ErrEmailTaken) {
+		return dto.UserResponse{}, exception.WrapConflictError(err, "email already registered")
+	}
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return helper.ToUserResponse(*user), nil
+}
+
+func (service *UserUsecaseImpl) Login(ctx context.Context, request dto.LoginRequest) (dto.LoginResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.LoginResponse{}, invalid(err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+
+	// pesan sama untuk email tidak terdaftar dan password salah
+	user, err := service.repository.FindByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.LoginResponse{}, exception.NewUnauthorizedError("invalid email or password")
+	}
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(request.Password)) != nil {
+		return dto.LoginResponse{}, exception.NewUnauthorizedError("invalid email or password")
+	}
+
+	token, expiresAt, err := service.tokens.GenerateToken(*user)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+
+	return dto.LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+		User:      helper.ToUserResponse(*user),
+	}, nil
+}
+
+func (service *UserUsecaseImpl) UpdateRole(ctx context.Context, id uuid.UUID, request dto.UpdateUserRoleRequest) (dto.UserResponse, error) {
+	err := service.Validate.Struct(request)
+	if err != nil {
+		return dto.UserResponse{}, invalid(err)
+	}
+
+	user, err := service.repository.FindByID(ctx, id)
+	if err != nil {
+		return dto.UserResponse{}, notFound(err, "User not found")
+	}
+
+	user.Role = model.Role(request.Role)
+
+	user, err = service.repository.UpdateRole(ctx, user)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return helper.ToUserResponse(*user), nil
+}
@@ -0,0 +1,99 @@
+// Package validation validates request DTOs and reports failed rules per
+// field in the language of the client.
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+// uni holds a translator per supported locale, English is the fallback
+var uni = ut.New(en.New(), en.New(), id.New())
+
+var (
+	once     sync.Once
+	validate *validator.Validate
+	errInit  error
+)
+
+// New returns the validator of request DTOs. Fields are named by their form
+// or json tag and failed rules are translated for every locale of uni.
+// Translations can only be registered once, so every caller shares the same
+// validator, which is safe for concurrent use.
+func New() (*validator.Validate, error) {
+	once.Do(func() {
+		v := validator.New()
+		v.RegisterTagNameFunc(fieldName)
+
+		registers := map[string]func(*validator.Validate, ut.Translator) error{
+			"en": en_translations.RegisterDefaultTranslations,
+			"id": id_translations.RegisterDefaultTranslations,
+		}
+		for locale, register := range registers {
+			trans, _ := uni.GetTranslator(locale)
+			if err := register(v, trans); err != nil {
+				errInit = fmt.Errorf("register %s validation translations: %w", locale, err)
+				return
+			}
+		}
+		validate = v
+	})
+	return validate, errInit
+}
+
+// fieldName names a field the way the client sent it
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"form", "json"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Translator returns the translator for an Accept-Language header. Languages
+// are tried in the order listed and English is used when none is supported.
+func Translator(acceptLanguage string) ut.Translator {
+	var locales []string
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ReplaceAll(tag, "-", "_")
+		base, _, _ := strings.Cut(tag, "_")
+		locales = append(locales, tag, base)
+	}
+
+	trans, _ := uni.FindTranslator(locales...)
+	return trans
+}
+
+// FieldErrors converts the failed rules of err into translated field errors
+func FieldErrors(err validator.ValidationErrors, trans ut.Translator) []dto.FieldError {
+	fields := make([]dto.FieldError, 0, len(err))
+	for _, fe := range err {
+		message := fe.Translate(trans)
+		if message == fe.Error() {
+			// rule tanpa terjemahan
+			message = fmt.Sprintf("%s failed on the %s rule", fe.Field(), fe.Tag())
+		}
+		fields = append(fields, dto.FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: message,
+		})
+	}
+	return fields
+}
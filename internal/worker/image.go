@@ -0,0 +1,146 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+)
+
+// imageJobs runs the image.process and image.delete jobs and mirrors their
+// progress on the image_status of the row they belong to
+type imageJobs struct {
+	db      *gorm.DB
+	storage storage.ImageStorage
+	staging *upload.Staging
+}
+
+// RegisterImageJobs adds the image handlers to the queue
+func RegisterImageJobs(q *Queue, db *gorm.DB, imageStorage storage.ImageStorage, staging *upload.Staging, concurrency int) {
+	h := &imageJobs{db: db, storage: imageStorage, staging: staging}
+
+	Register(q, model.JobImageProcess, Options{
+		Concurrency:   concurrency,
+		OnStateChange: h.mirrorStatus,
+	}, h.process)
+	Register(q, model.JobImageDelete, Options{
+		Concurrency:   concurrency,
+		OnStateChange: h.mirrorStatus,
+	}, h.delete)
+}
+
+// storageKey is the key of the job's image in the image storage
+func storageKey(payload model.ImageJobPayload) string {
+	if payload.SourceTable == model.SourceSightings {
+		return "sightings/" + payload.SourceID.String()
+	}
+	return payload.SourceID.String() // key = ID missing person
+}
+
+func (h *imageJobs) process(ctx context.Context, job model.Job, payload model.ImageJobPayload) error {
+	slog.InfoContext(ctx, "processing image", "source_table", payload.SourceTable, "source_id", payload.SourceID)
+	// 1️⃣ Bangun path file di staging
+	localPath := h.staging.Path(payload.PhotoID)
+
+	// 2️⃣ Resize ke semua rendition lalu upload ke image storage
+	photos, err := h.uploadRenditions(ctx, payload, localPath)
+	if err != nil {
+		return err
+	}
+
+	// 3️⃣ Update DB: URL rendition + status
+	// laporan yang ditarik saat diproses tetap jadi ready,
+	// lalu asset-nya dihapus oleh delete job
+	updateCtx, span := tracing.Start(ctx, "image.update_row")
+	err = h.db.WithContext(updateCtx).
+		Table(payload.SourceTable).
+		Where("id = ?", payload.SourceID).
+		Updates(map[string]any{
+			"photo_thumbnail": photos.Thumbnail,
+			"photo_card":      photos.Card,
+			"photo_full":      photos.Full,
+			"image_status":    model.Ready,
+		}).Error
+	tracing.Finish(span, err)
+	if err != nil {
+		return err
+	}
+
+	// 4️⃣ (OPSIONAL) hapus file lokal
+	_ = os.Remove(localPath)
+	slog.InfoContext(ctx, "image ready", "source_table", payload.SourceTable, "source_id", payload.SourceID)
+	return nil
+}
+
+func (h *imageJobs) delete(ctx context.Context, job model.Job, payload model.ImageJobPayload) error {
+	slog.InfoContext(ctx, "removing image of retracted report", "source_table", payload.SourceTable, "source_id", payload.SourceID)
+
+	// 1️⃣ Hapus file lokal yang belum sempat diupload
+	_ = os.Remove(h.staging.Path(payload.PhotoID))
+
+	// 2️⃣ Hapus semua rendition di storage, lalu key lama tanpa rendition.
+	// Di local storage key lama adalah direktori rendition, jadi harus
+	// dihapus terakhir setelah direktorinya kosong
+	keys := make([]string, 0, len(renditions)+1)
+	for _, r := range renditions {
+		keys = append(keys, renditionKey(payload, r))
+	}
+	keys = append(keys, storageKey(payload))
+	deleteCtx, span := tracing.Start(ctx, "image.delete_storage", attribute.Int("image.keys", len(keys)))
+	for _, key := range keys {
+		if err := h.storage.Delete(deleteCtx, key); err != nil {
+			tracing.Finish(span, err)
+			return err
+		}
+	}
+	span.End()
+
+	// 3️⃣ Update DB: status
+	updateCtx, span := tracing.Start(ctx, "image.update_row")
+	err := h.db.WithContext(updateCtx).
+		Table(payload.SourceTable).
+		Where("id = ?", payload.SourceID).
+		Update("image_status", model.Deleted).Error
+	tracing.Finish(span, err)
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "image removed", "source_table", payload.SourceTable, "source_id", payload.SourceID)
+	return nil
+}
+
+// mirrorStatus keeps the image_status of the row in line with its job
+func (h *imageJobs) mirrorStatus(tx *gorm.DB, job model.Job, status model.JobStatus) error {
+	var payload model.ImageJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return err
+	}
+
+	var imageStatus model.ImageStatus
+	switch {
+	case status == model.JobFailed:
+		imageStatus = model.Failed
+	case job.Kind == model.JobImageDelete && status == model.JobProcessing:
+		imageStatus = model.Deleting
+	case job.Kind == model.JobImageProcess && status == model.JobProcessing:
+		imageStatus = model.Processing
+	case job.Kind == model.JobImageProcess && status == model.JobPending:
+		imageStatus = model.Pending
+	default:
+		// done ditulis handler, delete job tetap "deleting" sampai selesai
+		return nil
+	}
+
+	return tx.
+		Table(payload.SourceTable).
+		Where("id = ?", payload.SourceID).
+		Update("image_status", imageStatus).Error
+}
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// leaseDuration is how long a claimed job stays with its worker
+	// without a heartbeat
+	leaseDuration = 2 * time.Minute
+
+	heartbeatInterval = leaseDuration / 3
+	reapInterval      = leaseDuration / 2
+)
+
+// errLeaseLost is returned when a job was reaped from this worker, another
+// worker may already be running it
+var errLeaseLost = errors.New("job lease lost")
+
+// newWorkerID identifies a worker instance in claimed_by
+func newWorkerID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), uuid.NewString()[:8])
+}
+
+// leased limits a query to the attempt of job this instance claimed. The
+// attempt number is the lease token: when the job was reaped and claimed
+// again, even by this instance, the old attempt no longer matches.
+func (q *Queue) leased(job model.Job) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("id = ? AND claimed_by = ? AND status = ? AND attempts = ?", job.ID, q.id, model.JobProcessing, job.Attempts)
+	}
+}
+
+// keepLease extends the lease of a job while it is being processed. The
+// returned context is cancelled once the lease is lost so the job stops
+// early; call stop when the job is done.
+func (q *Queue) keepLease(ctx context.Context, job model.Job) (context.Context, context.CancelFunc) {
+	jobCtx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-jobCtx.Done():
+				return
+			case <-ticker.C:
+				result := q.db.WithContext(jobCtx).
+					Model(&model.Job{}).
+					Scopes(q.leased(job)).
+					Update("lease_expires_at", time.Now().Add(leaseDuration))
+				if result.Error != nil {
+					// lease masih berlaku sampai kedaluwarsa, coba lagi nanti
+					slog.WarnContext(ctx, "lease heartbeat failed", "error", result.Error)
+					continue
+				}
+				if result.RowsAffected == 0 {
+					slog.WarnContext(ctx, "job lease lost")
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return jobCtx, cancel
+}
+
+// Release returns the jobs this instance is still running to pending, for a
+// shutdown that can not wait for them any longer. The interrupted attempt is
+// not counted, the job was not at fault.
+func (q *Queue) Release(ctx context.Context) (int, error) {
+	var jobs []model.Job
+
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("claimed_by = ? AND status = ?", q.id, model.JobProcessing).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Find(&jobs).Error
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			err := tx.
+				Model(&model.Job{}).
+				Scopes(q.leased(job)).
+				Updates(map[string]any{
+					"status":           model.JobPending,
+					"attempts":         gorm.Expr("attempts - 1"),
+					"run_at":           time.Now(),
+					"last_error":       "worker shut down",
+					"lease_expires_at": nil,
+				}).Error
+			if err != nil {
+				return err
+			}
+
+			if err := q.kinds[job.Kind].onStateChange(tx, job, model.JobPending); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(jobs), nil
+}
+
+// ReapExpiredLeases returns jobs whose worker stopped sending heartbeats to
+// pending, or dead-letters them when they have no attempts left
+func (q *Queue) ReapExpiredLeases(ctx context.Context) (int, error) {
+	var jobs []model.Job
+
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("status = ? AND lease_expires_at < NOW()", model.JobProcessing).
+			Clauses(clause.Locking{
+				Strength: "UPDATE",
+				Options:  "SKIP LOCKED",
+			}).
+			Find(&jobs).Error
+		if err != nil {
+			return err
+		}
+
+		for _, job := range jobs {
+			// kind yang tidak terdaftar di instance ini pakai default
+			reg := q.kinds[job.Kind]
+			limit := maxAttempts
+			if reg != nil {
+				limit = reg.opts.MaxAttempts
+			}
+
+			updates := map[string]any{
+				"last_error":       "lease expired",
+				"lease_expires_at": nil,
+			}
+
+			status := model.JobPending
+			if job.Attempts >= limit {
+				status = model.JobFailed
+			} else {
+				updates["run_at"] = time.Now()
+			}
+			updates["status"] = status
+
+			err := tx.
+				Model(&model.Job{}).
+				Where("id = ?", job.ID).
+				Updates(updates).Error
+			if err != nil {
+				return err
+			}
+
+			if err := reg.onStateChange(tx, job, status); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(jobs), nil
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// Listener waits for Postgres notifications about new jobs on its own
+// connection, outside the gorm pool
+type Listener struct {
+	dsn      string
+	onNotify func(kind string)
+}
+
+func NewListener(dsn string, onNotify func(kind string)) *Listener {
+	return &Listener{dsn: dsn, onNotify: onNotify}
+}
+
+// Start listens until ctx is cancelled, reconnecting with backoff when the
+// connection drops
+func (l *Listener) Start(ctx context.Context) {
+	delay := time.Second
+
+	for {
+		started := time.Now()
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// koneksi yang sempat stabil mulai lagi dari delay awal
+		if time.Since(started) > time.Minute {
+			delay = time.Second
+		}
+		slog.Error("listen for jobs failed", "error", err, "retry_in", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, time.Minute)
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, l.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{model.JobsChannel}.Sanitize()); err != nil {
+		return err
+	}
+	slog.Info("listening for jobs", "channel", model.JobsChannel)
+
+	// notifikasi yang terlewat selama tidak terhubung
+	l.onNotify("")
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.onNotify(notification.Payload)
+	}
+}
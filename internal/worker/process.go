@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const reconcileInterval = 10 * time.Minute
+
+// Process is everything a worker process runs next to (or without) the API
+type Process struct {
+	Queue      *Queue
+	Listener   *Listener
+	Reconciler *Reconciler
+}
+
+// Start runs the queue, its listener and the reconciler until ctx is
+// cancelled, and returns once all of them stopped
+func (p *Process) Start(ctx context.Context, pollInterval time.Duration) {
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		p.Queue.Start(ctx, pollInterval)
+	}()
+	go func() {
+		defer wg.Done()
+		p.Listener.Start(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		p.Reconciler.Start(ctx, reconcileInterval)
+	}()
+
+	wg.Wait()
+}
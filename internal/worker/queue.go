@@ -0,0 +1,391 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Handler runs one job, returning an error schedules a retry
+type Handler func(ctx context.Context, job model.Job) error
+
+// StateHook is called in the transaction that moves a job to a new status,
+// so rows that mirror the progress of their job stay in sync with it
+type StateHook func(tx *gorm.DB, job model.Job, status model.JobStatus) error
+
+// Options configure how the jobs of one kind are run
+type Options struct {
+	// jobs of this kind running at the same time, default 1
+	Concurrency int
+
+	// attempts before the job is dead-lettered, default maxAttempts
+	MaxAttempts int
+
+	OnStateChange StateHook
+}
+
+type registration struct {
+	kind    string
+	handler Handler
+	opts    Options
+
+	// wake makes the dispatcher claim right away instead of at the next poll
+	wake chan struct{}
+}
+
+// Queue runs the jobs of the jobs table with the handler registered for
+// their kind. Several instances may share the table, jobs are claimed with
+// FOR UPDATE SKIP LOCKED and leased to the instance that claimed them.
+type Queue struct {
+	// Fields
+	id    string
+	db    *gorm.DB
+	kinds map[string]*registration
+}
+
+func NewQueue(db *gorm.DB) *Queue {
+	return &Queue{
+		id:    newWorkerID(),
+		db:    db,
+		kinds: make(map[string]*registration),
+	}
+}
+
+// Register adds the handler for a job kind, the job payload is decoded into T
+func Register[T any](q *Queue, kind string, opts Options, handle func(ctx context.Context, job model.Job, payload T) error) {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 1
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = maxAttempts
+	}
+
+	q.kinds[kind] = &registration{
+		kind: kind,
+		handler: func(ctx context.Context, job model.Job) error {
+			var payload T
+			if err := json.Unmarshal(job.Payload, &payload); err != nil {
+				return fmt.Errorf("decode payload: %w", err)
+			}
+			return handle(ctx, job, payload)
+		},
+		opts: opts,
+		wake: make(chan struct{}, 1),
+	}
+}
+
+// Notify wakes up the dispatcher of a kind, or of every kind when kind is
+// empty. Unknown kinds are ignored, another instance may run them.
+func (q *Queue) Notify(kind string) {
+	for _, reg := range q.kinds {
+		if kind == "" || reg.kind == kind {
+			reg.poke()
+		}
+	}
+}
+
+func (reg *registration) poke() {
+	select {
+	case reg.wake <- struct{}{}:
+	default:
+		// sudah ada wakeup yang menunggu
+	}
+}
+
+// Start runs the registered kinds until ctx is cancelled. Jobs are claimed
+// when Notify is called and a finished job frees a slot; interval is only a
+// fallback for missed notifications and retries that became due. Jobs that
+// are already running are finished before Start returns.
+func (q *Queue) Start(ctx context.Context, interval time.Duration) {
+	slog.Info("job queue started", "worker_id", q.id)
+
+	var wg sync.WaitGroup
+	for _, reg := range q.kinds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.dispatch(ctx, reg, interval)
+		}()
+	}
+
+	// Start the reaper for jobs of crashed workers
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(reapInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reaped, err := q.ReapExpiredLeases(ctx)
+				if err != nil {
+					slog.Error("reap expired leases failed", "error", err)
+					continue
+				}
+				if reaped > 0 {
+					slog.Warn("returned jobs with expired leases", "count", reaped)
+				}
+			}
+		}
+	}()
+
+	// Wait for all kinds to finish
+	wg.Wait()
+	slog.Info("job queue stopped")
+}
+
+// dispatch claims jobs of one kind as long as it has free slots. Jobs are
+// only claimed for free slots, so a claimed job always starts right away.
+func (q *Queue) dispatch(ctx context.Context, reg *registration, interval time.Duration) {
+	slog.Info("running jobs", "kind", reg.kind, "concurrency", reg.opts.Concurrency)
+
+	slots := make(chan struct{}, reg.opts.Concurrency)
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// job yang sudah antri sebelum start langsung diambil
+	reg.poke()
+
+	for {
+		select {
+		// Stop claiming if the context is done
+		case <-ctx.Done():
+			return
+
+		// Fetch jobs on notification or fallback poll
+		case <-reg.wake:
+		case <-ticker.C:
+		}
+
+		free := cap(slots) - len(slots)
+		if free == 0 {
+			continue
+		}
+
+		jobs, err := q.claim(ctx, reg, free)
+		if err != nil {
+			slog.Error("claim jobs failed", "kind", reg.kind, "error", err)
+			continue
+		}
+
+		metrics.JobsClaimed.WithLabelValues(reg.kind).Add(float64(len(jobs)))
+
+		for _, job := range jobs {
+			slots <- struct{}{}
+			running.Add(1)
+			metrics.JobsBusy.WithLabelValues(reg.kind).Inc()
+			go func() {
+				defer func() {
+					metrics.JobsBusy.WithLabelValues(reg.kind).Dec()
+					<-slots
+					running.Done()
+
+					// slot kosong, ambil job berikutnya yang mungkin menunggu
+					reg.poke()
+				}()
+				q.run(ctx, reg, job)
+			}()
+		}
+	}
+}
+
+// claim takes due pending jobs of one kind, highest priority first. A job
+// waits while an older job with the same ordering key is still open.
+func (q *Queue) claim(ctx context.Context, reg *registration, limit int) ([]model.Job, error) {
+	// Get matching jobs
+	var jobs []model.Job
+
+	now := time.Now()
+	leaseExpiresAt := now.Add(leaseDuration)
+
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Use a lock so instances never claim the same job
+		err := tx.
+			Where("kind = ? AND status = ? AND run_at <= NOW()", reg.kind, model.JobPending).
+			Where(`ordering_key = '' OR NOT EXISTS (
+				SELECT 1 FROM jobs e
+				WHERE e.ordering_key = jobs.ordering_key
+				AND e.status IN ?
+				AND (e.created_at, e.id) < (jobs.created_at, jobs.id)
+			)`, []model.JobStatus{model.JobPending, model.JobProcessing}).
+			Order("priority DESC, run_at, created_at").
+			Clauses(clause.Locking{
+				Strength: "UPDATE",
+				Options:  "SKIP LOCKED",
+			}).
+			Limit(limit).
+			Find(&jobs).Error
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+
+		// Lease the claimed jobs to this instance, a claim counts as an
+		// attempt even when the worker dies before finishing it
+		ids := make([]uuid.UUID, 0, len(jobs))
+		for _, job := range jobs {
+			ids = append(ids, job.ID)
+		}
+
+		err = tx.
+			Model(&model.Job{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"status":           model.JobProcessing,
+				"attempts":         gorm.Expr("attempts + 1"),
+				"claimed_at":       now,
+				"claimed_by":       q.id,
+				"lease_expires_at": leaseExpiresAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		for i := range jobs {
+			jobs[i].Status = model.JobProcessing
+			jobs[i].Attempts++
+			jobs[i].ClaimedAt = &now
+			jobs[i].ClaimedBy = q.id
+			jobs[i].LeaseExpiresAt = &leaseExpiresAt
+
+			if err := reg.onStateChange(tx, jobs[i], model.JobProcessing); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// run processes a job while holding its lease. A started job is not
+// cancelled by a shutdown so uploads are not cut off halfway.
+func (q *Queue) run(ctx context.Context, reg *registration, job model.Job) {
+	// log dan trace job menyambung ke request yang membuatnya
+	ctx = logger.WithRequestID(ctx, job.RequestID)
+	ctx = logger.WithAttrs(ctx,
+		slog.String("job_id", job.ID.String()),
+		slog.String("kind", job.Kind),
+		slog.Int("attempt", job.Attempts),
+	)
+	ctx, span := tracing.Start(tracing.Extract(ctx, job.TraceContext), "job "+job.Kind,
+		attribute.String("job.id", job.ID.String()),
+		attribute.String("job.kind", job.Kind),
+		attribute.Int("job.attempt", job.Attempts),
+		attribute.Float64("job.queued_seconds", time.Since(job.CreatedAt).Seconds()),
+	)
+	defer span.End()
+
+	jobCtx, stop := q.keepLease(context.WithoutCancel(ctx), job)
+	defer stop()
+
+	start := time.Now()
+	err := reg.handler(jobCtx, job)
+	if err != nil {
+		tracing.Fail(span, err)
+		metrics.JobDuration.WithLabelValues(job.Kind, "failure").Observe(time.Since(start).Seconds())
+		slog.WarnContext(jobCtx, "job attempt failed", "error", err)
+		q.fail(jobCtx, reg, job, err)
+		return
+	}
+	metrics.JobDuration.WithLabelValues(job.Kind, "success").Observe(time.Since(start).Seconds())
+
+	err = q.finish(jobCtx, reg, job, model.JobDone, map[string]any{
+		"status":           model.JobDone,
+		"last_error":       "",
+		"lease_expires_at": nil,
+	})
+	if err != nil {
+		tracing.Fail(span, err)
+		slog.ErrorContext(jobCtx, "store job result failed", "error", err)
+		q.fail(jobCtx, reg, job, err)
+		return
+	}
+	metrics.JobsSucceeded.WithLabelValues(job.Kind).Inc()
+	slog.InfoContext(jobCtx, "job done", "duration", time.Since(start))
+}
+
+// fail schedules the next attempt with backoff, or dead-letters the job once
+// it has used all its attempts
+func (q *Queue) fail(ctx context.Context, reg *registration, job model.Job, jobErr error) {
+	// job sudah dikembalikan oleh reaper, worker lain yang melanjutkan
+	if errors.Is(jobErr, errLeaseLost) {
+		return
+	}
+
+	updates := map[string]any{
+		"last_error":       jobErr.Error(),
+		"lease_expires_at": nil,
+	}
+
+	status := model.JobPending
+	if job.Attempts >= reg.opts.MaxAttempts {
+		slog.ErrorContext(ctx, "job dead-lettered", "error", jobErr)
+		status = model.JobFailed
+	} else {
+		delay := retryDelay(job.Attempts)
+		slog.InfoContext(ctx, "job scheduled for retry", "delay", delay.Round(time.Second), "max_attempts", reg.opts.MaxAttempts)
+		updates["run_at"] = time.Now().Add(delay)
+	}
+	updates["status"] = status
+
+	if err := q.finish(ctx, reg, job, status, updates); err != nil {
+		slog.ErrorContext(ctx, "store job result failed", "error", err)
+		return
+	}
+	metrics.JobsFailed.WithLabelValues(job.Kind, strconv.FormatBool(status == model.JobFailed)).Inc()
+}
+
+// finish stores the job's new state. It returns errLeaseLost without
+// changing anything when the job is no longer leased to this instance.
+func (q *Queue) finish(
+	ctx context.Context,
+	reg *registration,
+	job model.Job,
+	status model.JobStatus,
+	updates map[string]any,
+) error {
+	return q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&model.Job{}).
+			Scopes(q.leased(job)).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errLeaseLost
+		}
+
+		return reg.onStateChange(tx, job, status)
+	})
+}
+
+func (reg *registration) onStateChange(tx *gorm.DB, job model.Job, status model.JobStatus) error {
+	if reg == nil || reg.opts.OnStateChange == nil {
+		return nil
+	}
+	return reg.opts.OnStateChange(tx, job, status)
+}
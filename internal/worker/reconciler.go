@@ -0,0 +1,158 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reconciler repairs what the outbox cannot guarantee on its own: uploads
+// whose request failed after the file was staged, and rows left pending
+// without a job (rows created before the outbox existed, manual edits).
+type Reconciler struct {
+	db      *gorm.DB
+	staging *upload.Staging
+
+	// files and rows younger than this may still be in flight
+	grace time.Duration
+}
+
+func NewReconciler(db *gorm.DB, staging *upload.Staging) *Reconciler {
+	return &Reconciler{db: db, staging: staging, grace: time.Hour}
+}
+
+func (r *Reconciler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reconcile(ctx); err != nil {
+				slog.Error("reconcile failed", "error", err)
+			}
+		}
+	}
+}
+
+// Reconcile runs one repair pass
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	// staging yang tidak terjangkau bukan berarti file-nya hilang,
+	// row tidak boleh ditandai failed
+	if err := r.staging.Ping(ctx); err != nil {
+		return fmt.Errorf("staging unreachable, skipping repair: %w", err)
+	}
+
+	for _, table := range []string{model.SourceMissingPersons, model.SourceSightings} {
+		if err := r.repairOrphanRows(ctx, table); err != nil {
+			return err
+		}
+	}
+
+	return r.removeOrphanFiles(ctx)
+}
+
+// repairOrphanRows requeues pending rows that have no open process job when
+// their staged file still exists, and marks them failed otherwise
+func (r *Reconciler) repairOrphanRows(ctx context.Context, table string) error {
+	var rows []struct {
+		ID      uuid.UUID
+		PhotoID string
+	}
+
+	err := r.db.WithContext(ctx).
+		Table(table).
+		Select("id, photo_id").
+		Where("image_status IN ?", []model.ImageStatus{model.Pending, model.Processing}).
+		Where("created_at < ?", time.Now().Add(-r.grace)).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM jobs j
+			WHERE j.ordering_key = ? || ':' || `+table+`.id::text
+			AND j.kind = ? AND j.status IN ?
+		)`, table, model.JobImageProcess, []model.JobStatus{model.JobPending, model.JobProcessing}).
+		Find(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if _, err := os.Stat(r.staging.Path(row.PhotoID)); err == nil {
+			slog.WarnContext(ctx, "requeue orphan row", "table", table, "id", row.ID)
+			err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Table(table).Where("id = ?", row.ID).Update("image_status", model.Pending).Error; err != nil {
+					return err
+				}
+
+				job := model.NewImageJob(model.JobImageProcess, table, row.ID, row.PhotoID)
+				if err := tx.Create(&job).Error; err != nil {
+					return err
+				}
+				return database.Notify(tx, model.JobsChannel, job.Kind)
+			})
+		} else {
+			slog.WarnContext(ctx, "staged photo is gone, marking row failed", "table", table, "id", row.ID)
+			err = r.db.WithContext(ctx).Table(table).Where("id = ?", row.ID).Update("image_status", model.Failed).Error
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeOrphanFiles deletes staged uploads that no open job refers to
+func (r *Reconciler) removeOrphanFiles(ctx context.Context) error {
+	entries, err := os.ReadDir(r.staging.Dir())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// failed job tetap menyimpan file supaya bisa dicoba ulang
+	var referenced []string
+	err = r.db.WithContext(ctx).
+		Model(&model.Job{}).
+		Where("kind = ? AND status IN ?", model.JobImageProcess,
+			[]model.JobStatus{model.JobPending, model.JobProcessing, model.JobFailed}).
+		Pluck("payload->>'photo_id'", &referenced).Error
+	if err != nil {
+		return err
+	}
+
+	inUse := make(map[string]bool, len(referenced))
+	for _, photoID := range referenced {
+		inUse[photoID] = true
+	}
+
+	cutoff := time.Now().Add(-r.grace)
+	for _, entry := range entries {
+		if entry.IsDir() || inUse[entry.Name()] {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+
+		slog.InfoContext(ctx, "removing orphan upload", "file", entry.Name())
+		if err := os.Remove(r.staging.Path(entry.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
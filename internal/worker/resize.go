@@ -0,0 +1,147 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// rendition is one resized version of an uploaded photo
+type rendition struct {
+	name   string
+	width  int
+	height int
+
+	// crop fills the box exactly, otherwise the photo fits inside it
+	crop bool
+}
+
+var renditions = []rendition{
+	{name: "thumbnail", width: 128, height: 128, crop: true},
+	{name: "card", width: 512, height: 512, crop: true},
+	{name: "full", width: 1600, height: 1600},
+}
+
+// renditionKey is the storage key of one rendition of the job's image
+func renditionKey(payload model.ImageJobPayload, r rendition) string {
+	return storageKey(payload) + "/" + r.name
+}
+
+// uploadRenditions decodes the original photo, resizes it to every rendition
+// and uploads them. Re-encoding drops all metadata, EXIF GPS included.
+func (h *imageJobs) uploadRenditions(ctx context.Context, payload model.ImageJobPayload, localPath string) (model.Photos, error) {
+	_, span := tracing.Start(ctx, "image.read")
+	src, err := decodeImage(localPath)
+	tracing.Finish(span, err)
+	if err != nil {
+		return model.Photos{}, err
+	}
+
+	urls := make(map[string]string, len(renditions))
+	for _, r := range renditions {
+		key := renditionKey(payload, r)
+
+		uploadCtx, span := tracing.Start(ctx, "image.upload", attribute.String("image.rendition", r.name))
+		err := h.uploadRendition(uploadCtx, r.name, key, resizeImage(src, r))
+		tracing.Finish(span, err)
+		if err != nil {
+			return model.Photos{}, fmt.Errorf("upload %s: %w", r.name, err)
+		}
+		urls[r.name] = h.storage.URL(key)
+	}
+
+	return model.Photos{
+		Thumbnail: urls["thumbnail"],
+		Card:      urls["card"],
+		Full:      urls["full"],
+	}, nil
+}
+
+func (h *imageJobs) uploadRendition(ctx context.Context, name string, key string, img image.Image) error {
+	tmp, err := os.CreateTemp("", "rendition-*.jpg")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := jpeg.Encode(tmp, img, &jpeg.Options{Quality: 85}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(tmp.Name())
+	if err != nil {
+		return err
+	}
+
+	if err := h.storage.Upload(ctx, key, tmp.Name()); err != nil {
+		return err
+	}
+	metrics.UploadBytes.WithLabelValues(name).Add(float64(info.Size()))
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("image.bytes", info.Size()))
+	return nil
+}
+
+// decodeImage reads a JPEG, PNG or WebP file and applies its EXIF
+// orientation so the photo stays upright once the metadata is gone
+func decodeImage(path string) (image.Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, exifOrientation(data))
+	}
+	return img, nil
+}
+
+// resizeImage scales src into the rendition box. Photos are never upscaled.
+func resizeImage(src image.Image, r rendition) image.Image {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	if r.crop {
+		// ambil area tengah dengan rasio yang sama dengan box
+		cropW, cropH := srcW, srcW*r.height/r.width
+		if cropH > srcH {
+			cropW, cropH = srcH*r.width/r.height, srcH
+		}
+		x := bounds.Min.X + (srcW-cropW)/2
+		y := bounds.Min.Y + (srcH-cropH)/2
+		srcRect := image.Rect(x, y, x+cropW, y+cropH)
+
+		dstW, dstH := min(r.width, cropW), min(r.height, cropH)
+		dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Src, nil)
+		return dst
+	}
+
+	scale := min(1, float64(r.width)/float64(srcW), float64(r.height)/float64(srcH))
+	dstW := max(1, int(float64(srcW)*scale))
+	dstH := max(1, int(float64(srcH)*scale))
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
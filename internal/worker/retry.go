@@ -0,0 +1,27 @@
+package worker
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	// maxAttempts is how often a job runs before it is dead-lettered
+	maxAttempts = 5
+
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = time.Hour
+)
+
+// retryDelay is the wait before the next attempt after the given number of
+// attempts: exponential backoff with equal jitter, so jobs that failed
+// together (e.g. storage outage) do not all retry at the same moment.
+func retryDelay(attempts int) time.Duration {
+	delay := retryMaxDelay
+	if attempts < 20 {
+		delay = min(retryBaseDelay<<max(attempts-1, 0), retryMaxDelay)
+	}
+
+	half := delay / 2
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_missing_persons_case_status;
+
+ALTER TABLE missing_persons
+DROP COLUMN updated_at,
+DROP COLUMN case_status;
@@ -0,0 +1,5 @@
+ALTER TABLE missing_persons
+ADD COLUMN case_status VARCHAR(20) NOT NULL DEFAULT 'open',
+ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_missing_persons_case_status ON missing_persons (case_status);
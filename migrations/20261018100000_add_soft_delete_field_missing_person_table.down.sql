@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_missing_persons_deleted_at;
+
+ALTER TABLE missing_persons
+DROP COLUMN deleted_at,
+DROP COLUMN retraction_reason,
+DROP COLUMN retracted_by;
@@ -0,0 +1,6 @@
+ALTER TABLE missing_persons
+ADD COLUMN retracted_by VARCHAR(100),
+ADD COLUMN retraction_reason TEXT,
+ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX idx_missing_persons_deleted_at ON missing_persons (deleted_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_missing_persons_created_at;
+DROP INDEX IF EXISTS idx_missing_persons_age;
+DROP INDEX IF EXISTS idx_missing_persons_search_vector;
+
+ALTER TABLE missing_persons
+DROP COLUMN search_vector;
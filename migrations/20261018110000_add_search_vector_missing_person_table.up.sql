@@ -0,0 +1,10 @@
+ALTER TABLE missing_persons
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(last_seen, '')), 'C')
+) STORED;
+
+CREATE INDEX idx_missing_persons_search_vector ON missing_persons USING GIN (search_vector);
+CREATE INDEX idx_missing_persons_age ON missing_persons (age);
+CREATE INDEX idx_missing_persons_created_at ON missing_persons (created_at);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_missing_persons_location;
+
+ALTER TABLE missing_persons
+DROP COLUMN province,
+DROP COLUMN city,
+DROP COLUMN place_name,
+DROP COLUMN longitude,
+DROP COLUMN latitude;
@@ -0,0 +1,8 @@
+ALTER TABLE missing_persons
+ADD COLUMN latitude DOUBLE PRECISION,
+ADD COLUMN longitude DOUBLE PRECISION,
+ADD COLUMN place_name VARCHAR(255),
+ADD COLUMN city VARCHAR(100),
+ADD COLUMN province VARCHAR(100);
+
+CREATE INDEX idx_missing_persons_location ON missing_persons (latitude, longitude);
@@ -0,0 +1,17 @@
+CREATE TABLE sightings (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    missing_person_id UUID NOT NULL REFERENCES missing_persons (id) ON DELETE CASCADE,
+    seen_at TIMESTAMP NOT NULL,
+    location VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    description TEXT NOT NULL,
+    reporter_name VARCHAR(100),
+    reporter_contact VARCHAR(100) NOT NULL,
+    photo_id VARCHAR(255),
+    image_status VARCHAR(20),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sightings_missing_person_id ON sightings (missing_person_id);
+CREATE INDEX idx_sightings_image_status ON sightings (image_status) WHERE image_status = 'pending';
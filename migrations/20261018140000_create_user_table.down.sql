@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_missing_persons_reporter_id;
+
+ALTER TABLE missing_persons
+DROP COLUMN reporter_id;
+
+DROP TABLE users;
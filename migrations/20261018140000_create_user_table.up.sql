@@ -0,0 +1,16 @@
+CREATE TABLE users (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'reporter',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_users_email ON users (email);
+
+ALTER TABLE missing_persons
+ADD COLUMN reporter_id UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_missing_persons_reporter_id ON missing_persons (reporter_id);
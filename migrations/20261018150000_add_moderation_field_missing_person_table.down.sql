@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_missing_persons_moderation_status;
+
+ALTER TABLE missing_persons
+DROP COLUMN moderated_at,
+DROP COLUMN moderated_by,
+DROP COLUMN rejection_reason,
+DROP COLUMN moderation_status;
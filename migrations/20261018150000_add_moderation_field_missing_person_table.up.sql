@@ -0,0 +1,10 @@
+ALTER TABLE missing_persons
+ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'pending_review',
+ADD COLUMN rejection_reason TEXT,
+ADD COLUMN moderated_by UUID,
+ADD COLUMN moderated_at TIMESTAMP;
+
+-- reports published before moderation existed stay public
+UPDATE missing_persons SET moderation_status = 'approved';
+
+CREATE INDEX idx_missing_persons_moderation_status ON missing_persons (moderation_status);
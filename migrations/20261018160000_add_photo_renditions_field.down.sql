@@ -0,0 +1,9 @@
+ALTER TABLE sightings
+DROP COLUMN photo_full,
+DROP COLUMN photo_card,
+DROP COLUMN photo_thumbnail;
+
+ALTER TABLE missing_persons
+DROP COLUMN photo_full,
+DROP COLUMN photo_card,
+DROP COLUMN photo_thumbnail;
@@ -0,0 +1,18 @@
+ALTER TABLE missing_persons
+ADD COLUMN photo_thumbnail VARCHAR(512),
+ADD COLUMN photo_card VARCHAR(512),
+ADD COLUMN photo_full VARCHAR(512);
+
+ALTER TABLE sightings
+ADD COLUMN photo_thumbnail VARCHAR(512),
+ADD COLUMN photo_card VARCHAR(512),
+ADD COLUMN photo_full VARCHAR(512);
+
+-- photos processed before renditions existed only have a single URL
+UPDATE missing_persons
+SET photo_thumbnail = photo_id, photo_card = photo_id, photo_full = photo_id
+WHERE image_status = 'ready' AND photo_id LIKE 'http%';
+
+UPDATE sightings
+SET photo_thumbnail = photo_id, photo_card = photo_id, photo_full = photo_id
+WHERE image_status = 'ready' AND photo_id LIKE 'http%';
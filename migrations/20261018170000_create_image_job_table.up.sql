@@ -0,0 +1,30 @@
+CREATE TABLE image_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(20) NOT NULL,
+    source_table VARCHAR(50) NOT NULL,
+    source_id UUID NOT NULL,
+    photo_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_image_jobs_source ON image_jobs (source_table, source_id);
+CREATE INDEX idx_image_jobs_status_created_at ON image_jobs (status, created_at);
+
+-- photos that were still waiting for the worker
+INSERT INTO image_jobs (kind, source_table, source_id, photo_id)
+SELECT 'process', 'missing_persons', id, photo_id
+FROM missing_persons
+WHERE image_status IN ('pending', 'processing');
+
+INSERT INTO image_jobs (kind, source_table, source_id, photo_id)
+SELECT 'process', 'sightings', id, photo_id
+FROM sightings
+WHERE image_status IN ('pending', 'processing');
+
+-- retracted reports whose photo has not been removed yet
+INSERT INTO image_jobs (kind, source_table, source_id, photo_id)
+SELECT 'delete', 'missing_persons', id, photo_id
+FROM missing_persons
+WHERE deleted_at IS NOT NULL AND image_status NOT IN ('deleted');
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_image_jobs_status_next_attempt_at;
+
+ALTER TABLE image_jobs
+DROP COLUMN next_attempt_at,
+DROP COLUMN last_error,
+DROP COLUMN attempts;
@@ -0,0 +1,6 @@
+ALTER TABLE image_jobs
+ADD COLUMN attempts INT NOT NULL DEFAULT 0,
+ADD COLUMN last_error TEXT,
+ADD COLUMN next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_image_jobs_status_next_attempt_at ON image_jobs (status, next_attempt_at);
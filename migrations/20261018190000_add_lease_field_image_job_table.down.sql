@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_image_jobs_status_lease_expires_at;
+
+ALTER TABLE image_jobs
+DROP COLUMN lease_expires_at,
+DROP COLUMN claimed_by,
+DROP COLUMN claimed_at;
@@ -0,0 +1,11 @@
+ALTER TABLE image_jobs
+ADD COLUMN claimed_at TIMESTAMP,
+ADD COLUMN claimed_by VARCHAR(100),
+ADD COLUMN lease_expires_at TIMESTAMP;
+
+CREATE INDEX idx_image_jobs_status_lease_expires_at ON image_jobs (status, lease_expires_at);
+
+-- job yang sudah processing tanpa lease langsung dianggap kedaluwarsa
+UPDATE image_jobs
+SET lease_expires_at = CURRENT_TIMESTAMP
+WHERE status = 'processing';
@@ -0,0 +1,39 @@
+CREATE TABLE image_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(20) NOT NULL,
+    source_table VARCHAR(50) NOT NULL,
+    source_id UUID NOT NULL,
+    photo_id VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP,
+    claimed_by VARCHAR(100),
+    lease_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_image_jobs_source ON image_jobs (source_table, source_id);
+CREATE INDEX idx_image_jobs_status_created_at ON image_jobs (status, created_at);
+CREATE INDEX idx_image_jobs_status_next_attempt_at ON image_jobs (status, next_attempt_at);
+CREATE INDEX idx_image_jobs_status_lease_expires_at ON image_jobs (status, lease_expires_at);
+
+-- job selain image tidak punya tempat di image_jobs
+INSERT INTO image_jobs (
+    id, kind, source_table, source_id, photo_id, status, attempts, last_error,
+    next_attempt_at, claimed_at, claimed_by, lease_expires_at, created_at, updated_at
+)
+SELECT
+    id,
+    substring(kind FROM 7),
+    payload->>'source_table',
+    (payload->>'source_id')::uuid,
+    payload->>'photo_id',
+    status, attempts, last_error,
+    run_at, claimed_at, claimed_by, lease_expires_at, created_at, updated_at
+FROM jobs
+WHERE kind IN ('image.process', 'image.delete');
+
+DROP TABLE jobs;
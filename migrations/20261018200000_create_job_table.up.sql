@@ -0,0 +1,40 @@
+CREATE TABLE jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    ordering_key VARCHAR(255) NOT NULL DEFAULT '',
+    priority INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    run_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    claimed_at TIMESTAMP,
+    claimed_by VARCHAR(100),
+    lease_expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_jobs_claim ON jobs (status, kind, run_at);
+CREATE INDEX idx_jobs_status_lease_expires_at ON jobs (status, lease_expires_at);
+CREATE INDEX idx_jobs_ordering_key ON jobs (ordering_key);
+
+-- pindahkan image job ke tabel jobs
+INSERT INTO jobs (
+    id, kind, payload, ordering_key, status, run_at, attempts, last_error,
+    claimed_at, claimed_by, lease_expires_at, created_at, updated_at
+)
+SELECT
+    id,
+    'image.' || kind,
+    jsonb_build_object(
+        'source_table', source_table,
+        'source_id', source_id,
+        'photo_id', COALESCE(photo_id, '')
+    ),
+    source_table || ':' || source_id::text,
+    status, next_attempt_at, attempts, last_error,
+    claimed_at, claimed_by, lease_expires_at, created_at, updated_at
+FROM image_jobs;
+
+DROP TABLE image_jobs;
@@ -0,0 +1,25 @@
+ALTER TABLE jobs
+ALTER COLUMN run_at TYPE TIMESTAMP,
+ALTER COLUMN claimed_at TYPE TIMESTAMP,
+ALTER COLUMN lease_expires_at TYPE TIMESTAMP,
+ALTER COLUMN created_at TYPE TIMESTAMP,
+ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE users
+ALTER COLUMN created_at TYPE TIMESTAMP,
+ALTER COLUMN updated_at TYPE TIMESTAMP;
+
+ALTER TABLE sightings
+ALTER COLUMN seen_at TYPE TIMESTAMP,
+ALTER COLUMN created_at TYPE TIMESTAMP;
+
+ALTER TABLE missing_persons
+ALTER COLUMN created_at TYPE TIMESTAMP,
+ALTER COLUMN updated_at TYPE TIMESTAMP,
+ALTER COLUMN deleted_at TYPE TIMESTAMP,
+ALTER COLUMN moderated_at TYPE TIMESTAMP;
+
+ALTER TABLE missing_persons ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE sightings ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE users ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE jobs ALTER COLUMN id SET DEFAULT uuid_generate_v4();
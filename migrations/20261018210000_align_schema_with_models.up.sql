@@ -0,0 +1,28 @@
+-- samakan dengan model, gen_random_uuid() sudah bawaan sejak Postgres 13
+ALTER TABLE missing_persons ALTER COLUMN id SET DEFAULT gen_random_uuid();
+ALTER TABLE sightings ALTER COLUMN id SET DEFAULT gen_random_uuid();
+ALTER TABLE users ALTER COLUMN id SET DEFAULT gen_random_uuid();
+ALTER TABLE jobs ALTER COLUMN id SET DEFAULT gen_random_uuid();
+
+-- gorm menulis time.Time sebagai timestamptz, tanpa zona waktu perbandingan
+-- dengan NOW() bergantung pada TimeZone session
+ALTER TABLE missing_persons
+ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+ALTER COLUMN updated_at TYPE TIMESTAMPTZ,
+ALTER COLUMN deleted_at TYPE TIMESTAMPTZ,
+ALTER COLUMN moderated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE sightings
+ALTER COLUMN seen_at TYPE TIMESTAMPTZ,
+ALTER COLUMN created_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE users
+ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
+
+ALTER TABLE jobs
+ALTER COLUMN run_at TYPE TIMESTAMPTZ,
+ALTER COLUMN claimed_at TYPE TIMESTAMPTZ,
+ALTER COLUMN lease_expires_at TYPE TIMESTAMPTZ,
+ALTER COLUMN created_at TYPE TIMESTAMPTZ,
+ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+ALTER TABLE jobs
+ADD COLUMN request_id VARCHAR(100) NOT NULL DEFAULT '';
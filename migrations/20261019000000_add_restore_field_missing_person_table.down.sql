@@ -0,0 +1,3 @@
+ALTER TABLE missing_persons
+DROP COLUMN restored_at,
+DROP COLUMN restored_by;
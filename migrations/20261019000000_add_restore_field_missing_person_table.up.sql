@@ -0,0 +1,4 @@
+-- retraction info tetap disimpan, restore dicatat terpisah
+ALTER TABLE missing_persons
+ADD COLUMN restored_by UUID,
+ADD COLUMN restored_at TIMESTAMPTZ;
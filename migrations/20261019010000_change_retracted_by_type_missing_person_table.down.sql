@@ -0,0 +1,2 @@
+ALTER TABLE missing_persons
+ALTER COLUMN retracted_by TYPE VARCHAR(100) USING retracted_by::text;
@@ -0,0 +1,3 @@
+-- samakan dengan restored_by, isinya selalu id user
+ALTER TABLE missing_persons
+ALTER COLUMN retracted_by TYPE UUID USING NULLIF(retracted_by, '')::uuid;
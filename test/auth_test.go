@@ -0,0 +1,182 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRegisterAndLoginSuccess(t *testing.T) {
+	truncateUsers(testDB)
+
+	// ===== register =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/auth/register",
+		strings.NewReader(`{"name":"Siti","email":"Siti@Example.com","password":"rahasia123"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].(map[string]any)
+	assert.Equal(t, "siti@example.com", data["email"])
+	assert.Equal(t, "reporter", data["role"])
+
+	// ===== login =====
+	req = httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/auth/login",
+		strings.NewReader(`{"email":"siti@example.com","password":"rahasia123"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	response = map[string]any{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data = response["data"].(map[string]any)
+	assert.NotEmpty(t, data["token"])
+	assert.Equal(t, "Bearer", data["token_type"])
+}
+
+func TestRegisterFailedDuplicateEmail(t *testing.T) {
+	truncateUsers(testDB)
+
+	body := `{"name":"Siti","email":"siti@example.com","password":"rahasia123"}`
+
+	for _, expected := range []int{http.StatusCreated, http.StatusConflict} {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+		testRouter.ServeHTTP(recorder, req)
+
+		assert.Equal(t, expected, recorder.Code)
+	}
+}
+
+// racingUserRepository misses the existing account in FindByEmail, like a
+// concurrent registration that committed right after the lookup
+type racingUserRepository struct {
+	repository.UserRepository
+}
+
+func (r racingUserRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestRegisterFailedConcurrentDuplicateEmail(t *testing.T) {
+	truncateUsers(testDB)
+
+	validate, err := validation.New()
+	assert.Nil(t, err)
+	userUsecase := usecase.NewUserUsecase(racingUserRepository{repository.NewUserRepository(testDB)}, validate, nil)
+	request := dto.RegisterRequest{Name: "Siti", Email: "siti@example.com", Password: "rahasia123"}
+
+	_, err = userUsecase.Register(context.Background(), request)
+	assert.Nil(t, err)
+
+	// ===== unique index ditolak sebagai conflict, bukan 500 =====
+	_, err = userUsecase.Register(context.Background(), request)
+
+	var conflict exception.ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "email already registered", conflict.Error())
+}
+
+func TestLoginFailedWrongPassword(t *testing.T) {
+	truncateUsers(testDB)
+
+	// ===== register =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/auth/register",
+		strings.NewReader(`{"name":"Siti","email":"siti@example.com","password":"rahasia123"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	// ===== login with wrong password =====
+	req = httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/auth/login",
+		strings.NewReader(`{"email":"siti@example.com","password":"salah12345"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	assert.Equal(t, float64(http.StatusUnauthorized), response["status"])
+}
+
+func TestUpdateUserRoleSuccess(t *testing.T) {
+	truncateUsers(testDB)
+	reporter, reporterToken := createUserWithToken(t, model.RoleReporter)
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	// ===== reporter cannot promote themselves =====
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/admin/users/"+reporter.ID.String()+"/role",
+		strings.NewReader(`{"role":"moderator"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", reporterToken)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// ===== admin promotes reporter =====
+	req = httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/admin/users/"+reporter.ID.String()+"/role",
+		strings.NewReader(`{"role":"moderator"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", adminToken)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var updated model.User
+	testDB.First(&updated, "id = ?", reporter.ID)
+	assert.Equal(t, model.RoleModerator, updated.Role)
+}
@@ -0,0 +1,180 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func setRequiredConfigEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_NAME", "missing_person")
+	t.Setenv("JWT_SECRET", "rahasia")
+	t.Setenv("STORAGE_DRIVER", "local")
+}
+
+func TestConfigLoadSuccess(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("PORT", "8080")
+	t.Setenv("JOB_POLL_INTERVAL", "30s")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+
+	cfg, err := config.Load()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 8080, cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Worker.PollInterval)
+	assert.Equal(t, 5, cfg.Upload.MaxSizeMB)
+	assert.Equal(t, "storage/tmp", cfg.Upload.StagingDir)
+	assert.Equal(t, "http://localhost:8080/images", cfg.Storage.Local.URL)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+	assert.Equal(t, 0.25, cfg.Tracing.SampleRatio)
+	assert.Equal(t, "none", cfg.Tracing.Exporter)
+	assert.False(t, cfg.Storage.S3.CreateBucket)
+}
+
+func TestConfigLoadFailedMissingRequired(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("DB_HOST", "")
+	t.Setenv("JWT_SECRET", "")
+
+	_, err := config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "DB_HOST is required")
+	assert.Contains(t, err.Error(), "JWT_SECRET is required")
+
+	// ===== staging dir dikosongkan lewat file =====
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("upload:\n  staging_dir: \"\"\n"), 0644))
+	t.Setenv("CONFIG_FILE", path)
+
+	_, err = config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "UPLOAD_STAGING_DIR is required")
+}
+
+func TestConfigLoadDatabaseOnly(t *testing.T) {
+	// migrate jalan tanpa JWT secret dan credential storage
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_NAME", "missing_person")
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("STORAGE_DRIVER", "s3")
+
+	_, err := config.Load()
+	assert.NotNil(t, err)
+
+	cfg, err := config.LoadDatabase()
+	assert.Nil(t, err)
+	assert.Equal(t, "localhost", cfg.Database.Host)
+
+	// ===== database tetap divalidasi =====
+	t.Setenv("DB_HOST", "")
+
+	_, err = config.LoadDatabase()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "DB_HOST is required")
+	assert.NotContains(t, err.Error(), "JWT_SECRET")
+}
+
+func TestConfigDatabaseDSNQuotesValues(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Host:     "localhost",
+		Port:     5432,
+		User:     "postgres",
+		Password: `rahasia kami 'o\k`,
+		Name:     "missing_person",
+		SSLMode:  "disable",
+	}
+
+	// ===== password dengan spasi, kutip dan backslash utuh =====
+	parsed, err := pgconn.ParseConfig(cfg.DSN())
+	assert.Nil(t, err)
+	assert.Equal(t, cfg.Password, parsed.Password)
+	assert.Equal(t, "postgres", parsed.User)
+	assert.Equal(t, "missing_person", parsed.Database)
+	assert.Equal(t, uint16(5432), parsed.Port)
+
+	// ===== nilai kosong tetap satu nilai =====
+	cfg.Password = ""
+	parsed, err = pgconn.ParseConfig(cfg.DSN())
+	assert.Nil(t, err)
+	assert.Equal(t, "", parsed.Password)
+	assert.Equal(t, "missing_person", parsed.Database)
+}
+
+func TestConfigLoadFailedInvalidValue(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("STORAGE_DRIVER", "ftp")
+
+	_, err := config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `STORAGE_DRIVER must be one of cloudinary, local, s3, got "ftp"`)
+
+	t.Setenv("STORAGE_DRIVER", "local")
+	t.Setenv("PORT", "abc")
+
+	_, err = config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "PORT")
+
+	t.Setenv("PORT", "3000")
+	t.Setenv("TRACING_SAMPLE_RATIO", "2")
+
+	_, err = config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "TRACING_SAMPLE_RATIO must be at most 1")
+}
+
+func TestConfigLoadFailedMissingCredentials(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("STORAGE_DRIVER", "s3")
+	t.Setenv("S3_ACCESS_KEY", "")
+	t.Setenv("S3_SECRET_KEY", "")
+
+	_, err := config.Load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "S3_ACCESS_KEY is required when STORAGE_DRIVER is s3")
+}
+
+func TestConfigLoadFromFile(t *testing.T) {
+	setRequiredConfigEnv(t)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(path, []byte(`
+server:
+  port: 4000
+upload:
+  max_size_mb: 10
+worker:
+  concurrency: 2
+`), 0644)
+	assert.Nil(t, err)
+
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("WORKER_CONCURRENCY", "8")
+
+	cfg, err := config.Load()
+	assert.Nil(t, err)
+
+	assert.Equal(t, 4000, cfg.Server.Port)
+	assert.Equal(t, 10, cfg.Upload.MaxSizeMB)
+	// env menang atas file
+	assert.Equal(t, 8, cfg.Worker.Concurrency)
+}
+
+func TestConfigLoadFailedMissingFile(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	_, err := config.Load()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,155 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/dto"
+	"github.com/Mhbib34/missing-person-service/internal/exception"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveError returns the response ErrorHandler writes for err
+func serveError(err error) *httptest.ResponseRecorder {
+	return serveErrorRequest(httptest.NewRequest(http.MethodGet, "/", nil), err)
+}
+
+func serveErrorRequest(req *http.Request, err error) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.GET("/", func(ctx *gin.Context) {
+		exception.ErrorHandler(ctx, err)
+	})
+
+	recorder := httptest.NewRecorder()
+	r.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestErrorHandlerMapsWrappedErrors(t *testing.T) {
+	cases := []struct {
+		err     error
+		code    int
+		message string
+	}{
+		{fmt.Errorf("find report: %w", exception.NewNotFoundError("Report not found")), http.StatusNotFound, "Report not found"},
+		{exception.WrapValidationError(errors.New("name is required"), "request validation failed"), http.StatusBadRequest, "request validation failed"},
+		{fmt.Errorf("requeue: %w", exception.NewConflictError("only failed jobs can be requeued")), http.StatusConflict, "only failed jobs can be requeued"},
+		{exception.NewForbiddenError("forbidden"), http.StatusForbidden, "forbidden"},
+		{exception.NewUnauthorizedError("invalid token"), http.StatusUnauthorized, "invalid token"},
+		{exception.WrapUnavailableError(errors.New("dial tcp: refused"), "storage unavailable"), http.StatusServiceUnavailable, "storage unavailable"},
+		// penyebab error internal tidak dikirim ke client
+		{errors.New("pq: relation does not exist"), http.StatusInternalServerError, "internal server error"},
+	}
+
+	for _, c := range cases {
+		recorder := serveError(c.err)
+		assert.Equal(t, c.code, recorder.Code, c.err.Error())
+
+		var response map[string]any
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+		assert.Equal(t, c.message, response["detail"])
+	}
+}
+
+func TestErrorHandlerRateLimited(t *testing.T) {
+	recorder := serveError(exception.NewRateLimitedError("too many attempts", 1500*time.Millisecond))
+
+	assert.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "2", recorder.Header().Get("Retry-After"))
+}
+
+func TestErrorHandlerTranslatesFieldErrors(t *testing.T) {
+	validate, err := validation.New()
+	assert.Nil(t, err)
+
+	err = validate.Struct(dto.RegisterRequest{Name: "Budi", Email: "bukan-email", Password: "rahasia123"})
+
+	// ===== bahasa dari Accept-Language =====
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+	recorder := serveErrorRequest(req, fmt.Errorf("register: %w", exception.WrapValidationError(err, "request validation failed")))
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, exception.ProblemContentType, recorder.Header().Get("Content-Type"))
+
+	var response dto.ProblemResponse
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "/problems/validation-error", response.Type)
+	assert.Equal(t, "request validation failed", response.Detail)
+	assert.Equal(t, []dto.FieldError{{
+		Field:   "email",
+		Rule:    "email",
+		Message: "email harus berupa alamat email yang valid",
+	}}, response.Errors)
+
+	// ===== bahasa lain kembali ke English =====
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "fr")
+	recorder = serveErrorRequest(req, err)
+
+	response = dto.ProblemResponse{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "email must be a valid email address", response.Errors[0].Message)
+}
+
+func TestErrorHandlerMalformedRequest(t *testing.T) {
+	// ===== id bukan UUID =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/bukan-uuid", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	var response dto.ProblemResponse
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "/problems/malformed-request", response.Type)
+	assert.Equal(t, "/api/v1/missing-persons/bukan-uuid", response.Instance)
+
+	// ===== JSON rusak =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", strings.NewReader(`{"name":`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	response = dto.ProblemResponse{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "/problems/malformed-request", response.Type)
+
+	// ===== tipe nilai salah =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", strings.NewReader(`{"name":123}`))
+	req.Header.Set("Content-Type", "application/json")
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	response = dto.ProblemResponse{}
+	assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "name", response.Errors[0].Field)
+	assert.Equal(t, "type", response.Errors[0].Rule)
+}
+
+func TestUsecaseReturnsTypedErrors(t *testing.T) {
+	// usecase dipanggil langsung tanpa HTTP, seperti dari worker atau CLI
+	jobUsecase := usecase.NewJobUsecase(repository.NewJobRepository(testDB))
+
+	_, err := jobUsecase.Requeue(context.Background(), uuid.New())
+
+	var notFound exception.NotFoundError
+	assert.True(t, errors.As(err, &notFound))
+	assert.Equal(t, "Job not found", notFound.Error())
+}
@@ -0,0 +1,144 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/controller"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthzSuccess(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestReadyzSuccess(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].(map[string]any)
+	assert.Equal(t, true, data["ready"])
+
+	checks := data["checks"].(map[string]any)
+	assert.Equal(t, "ok", checks["database"])
+	assert.Equal(t, "ok", checks["storage"])
+	assert.Equal(t, "ok", checks["staging"])
+	assert.Equal(t, "ok", checks["migrations"])
+}
+
+// unreachableStorage fails its ping with details that must stay in the log
+type unreachableStorage struct {
+	storage.ImageStorage
+}
+
+func (unreachableStorage) Ping(ctx context.Context) error {
+	return errors.New("dial tcp 10.0.0.5:9000: connect: connection refused")
+}
+
+func TestReadyzFailedHidesReason(t *testing.T) {
+	migrator, err := migration.NewMigrator(testDB)
+	assert.Nil(t, err)
+
+	healthUsecase := usecase.NewHealthUsecase(testDB, unreachableStorage{}, testStaging, migrator)
+	router := gin.New()
+	router.GET("/readyz", controller.NewHealthController(healthUsecase).Readiness)
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "10.0.0.5")
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].(map[string]any)
+	assert.Equal(t, false, data["ready"])
+
+	checks := data["checks"].(map[string]any)
+	assert.Equal(t, "fail", checks["storage"])
+	assert.Equal(t, "ok", checks["database"])
+}
+
+func TestWorkerStatusSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.png",
+		ImageStatus:      model.Pending,
+		ModerationStatus: model.Approved,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	// ===== job pending sejak 10 menit lalu =====
+	pending := model.NewImageJob(model.JobImageProcess, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	pending.CreatedAt = time.Now().Add(-10 * time.Minute)
+	assert.Nil(t, testDB.Create(&pending).Error)
+
+	// ===== job yang sedang dikerjakan =====
+	leaseExpiresAt := time.Now().Add(time.Minute)
+	busy := createTestJob(t, "test.busy", 1, 0, time.Now())
+	assert.Nil(t, testDB.Model(&busy).Updates(map[string]any{
+		"status":           model.JobProcessing,
+		"claimed_by":       "worker-1",
+		"lease_expires_at": leaseExpiresAt,
+	}).Error)
+
+	done := createTestJob(t, "test.done", 2, 0, time.Now())
+	assert.Nil(t, testDB.Model(&done).Update("status", model.JobDone).Error)
+
+	// ===== request =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/worker/status", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].(map[string]any)
+	assert.Equal(t, float64(1), data["busy"])
+	assert.Equal(t, float64(1), data["busy_by_kind"].(map[string]any)["test.busy"])
+	assert.Equal(t, float64(1), data["queue_depth"].(map[string]any)["pending"])
+	assert.GreaterOrEqual(t, data["oldest_pending_age_seconds"].(float64), float64(600))
+	assert.NotEmpty(t, data["last_success_at"])
+}
+
+func TestWorkerStatusAdminOnly(t *testing.T) {
+	_, moderatorToken := createUserWithToken(t, model.RoleModerator)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/worker/status", nil)
+	req.Header.Set("Authorization", moderatorToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
@@ -0,0 +1,137 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRequeueSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.png",
+		ImageStatus:      model.Failed,
+		ModerationStatus: model.Approved,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	// ===== dead-lettered job =====
+	job := model.NewImageJob(model.JobImageProcess, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	job.Status = model.JobFailed
+	job.Attempts = 5
+	job.LastError = "upload failed"
+	assert.Nil(t, testDB.Create(&job).Error)
+
+	// ===== listed as failed =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs?status=failed&kind=image.process", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 1)
+	assert.Equal(t, job.ID.String(), data[0].(map[string]any)["id"])
+	assert.Equal(t, "upload failed", data[0].(map[string]any)["last_error"])
+
+	// ===== requeue =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/jobs/"+job.ID.String()+"/requeue", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var requeued model.Job
+	assert.Nil(t, testDB.First(&requeued, "id = ?", job.ID).Error)
+	assert.Equal(t, model.JobPending, requeued.Status)
+	assert.Equal(t, 0, requeued.Attempts)
+	assert.Empty(t, requeued.LastError)
+
+	var updated model.MissingPersons
+	assert.Nil(t, testDB.First(&updated, "id = ?", missingPerson.ID).Error)
+	assert.Equal(t, model.Pending, updated.ImageStatus)
+
+	// ===== pending job cannot be requeued again =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/jobs/"+job.ID.String()+"/requeue", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestJobRequeueFailedRetractedReport(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.png",
+		ImageStatus:      model.Deleting,
+		ModerationStatus: model.Approved,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+	assert.Nil(t, testDB.Delete(&missingPerson).Error)
+
+	job := model.NewImageJob(model.JobImageProcess, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	job.Status = model.JobFailed
+	job.Attempts = 5
+	assert.Nil(t, testDB.Create(&job).Error)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/jobs/"+job.ID.String()+"/requeue", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	// ===== job dan laporan tidak berubah =====
+	var unchanged model.Job
+	assert.Nil(t, testDB.First(&unchanged, "id = ?", job.ID).Error)
+	assert.Equal(t, model.JobFailed, unchanged.Status)
+	assert.Equal(t, 5, unchanged.Attempts)
+
+	var retracted model.MissingPersons
+	assert.Nil(t, testDB.Unscoped().First(&retracted, "id = ?", missingPerson.ID).Error)
+	assert.Equal(t, model.Deleting, retracted.ImageStatus)
+}
+
+func TestJobAdminOnly(t *testing.T) {
+	_, moderatorToken := createUserWithToken(t, model.RoleModerator)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs", nil)
+	req.Header.Set("Authorization", moderatorToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+}
+
+func TestJobListInvalidStatus(t *testing.T) {
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/jobs?status=unknown", nil)
+	req.Header.Set("Authorization", adminToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,118 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReapExpiredLeases(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	newRow := func(name string) model.MissingPersons {
+		missingPerson := model.MissingPersons{
+			Name:        name,
+			Description: "celana pendek",
+			LastSeen:    "Medan",
+			Contact:     "08123456789",
+			PhotoID:     name + ".png",
+			ImageStatus: model.Processing,
+		}
+		assert.Nil(t, testDB.Create(&missingPerson).Error)
+		return missingPerson
+	}
+
+	newJob := func(row model.MissingPersons, attempts int, leaseExpiresAt time.Time) model.Job {
+		claimedAt := leaseExpiresAt.Add(-2 * time.Minute)
+		job := model.NewImageJob(model.JobImageProcess, model.SourceMissingPersons, row.ID, row.PhotoID)
+		job.Status = model.JobProcessing
+		job.Attempts = attempts
+		job.ClaimedAt = &claimedAt
+		job.ClaimedBy = "crashed-worker"
+		job.LeaseExpiresAt = &leaseExpiresAt
+		assert.Nil(t, testDB.Create(&job).Error)
+		return job
+	}
+
+	expiredRow := newRow("joko")
+	expired := newJob(expiredRow, 1, time.Now().Add(-time.Minute))
+
+	exhaustedRow := newRow("budi")
+	exhausted := newJob(exhaustedRow, 5, time.Now().Add(-time.Minute))
+
+	activeRow := newRow("siti")
+	active := newJob(activeRow, 1, time.Now().Add(time.Minute))
+
+	// ===== reap =====
+	queue := worker.NewQueue(testDB)
+	worker.RegisterImageJobs(queue, testDB, nil, testStaging, 1)
+
+	reaped, err := queue.ReapExpiredLeases(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, reaped)
+
+	// ===== expired lease goes back to pending =====
+	var job model.Job
+	assert.Nil(t, testDB.First(&job, "id = ?", expired.ID).Error)
+	assert.Equal(t, model.JobPending, job.Status)
+	assert.Nil(t, job.LeaseExpiresAt)
+
+	var row model.MissingPersons
+	assert.Nil(t, testDB.First(&row, "id = ?", expiredRow.ID).Error)
+	assert.Equal(t, model.Pending, row.ImageStatus)
+
+	// ===== no attempts left, dead-lettered =====
+	assert.Nil(t, testDB.First(&job, "id = ?", exhausted.ID).Error)
+	assert.Equal(t, model.JobFailed, job.Status)
+
+	assert.Nil(t, testDB.First(&row, "id = ?", exhaustedRow.ID).Error)
+	assert.Equal(t, model.Failed, row.ImageStatus)
+
+	// ===== live lease is untouched =====
+	assert.Nil(t, testDB.First(&job, "id = ?", active.ID).Error)
+	assert.Equal(t, model.JobProcessing, job.Status)
+	assert.Equal(t, "crashed-worker", job.ClaimedBy)
+}
+
+func TestStaleAttemptCannotFinishJob(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	started := make(chan model.Job, 2)
+	release := make(chan struct{})
+
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.stale", worker.Options{Concurrency: 1}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		started <- job
+		<-release
+		return nil
+	})
+
+	job := createTestJob(t, "test.stale", 1, 0, time.Now().Add(-time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.Start(ctx, 20*time.Millisecond)
+	}()
+
+	first := <-started
+	assert.Equal(t, 1, first.Attempts)
+
+	// ===== lease di-reap lalu diambil lagi oleh instance yang sama =====
+	assert.Nil(t, testDB.Model(&model.Job{}).Where("id = ?", job.ID).Update("attempts", 2).Error)
+
+	// attempt pertama selesai, hasilnya tidak boleh disimpan
+	close(release)
+	cancel()
+	<-done
+
+	var stored model.Job
+	assert.Nil(t, testDB.First(&stored, "id = ?", job.ID).Error)
+	assert.Equal(t, model.JobProcessing, stored.Status)
+	assert.Equal(t, 2, stored.Attempts)
+}
@@ -0,0 +1,117 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/logger"
+	"github.com/Mhbib34/missing-person-service/internal/middleware"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggerRedactsPII(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(&buf, config.LogConfig{Level: "info"})
+
+	ctx := logger.WithRequestID(context.Background(), "req-123")
+	log.InfoContext(ctx, "report created",
+		"name", "Joko",
+		"contact", "08123456789",
+		slog.Group("reporter", "reporter_contact", "08987654321"),
+		"city", "Medan",
+	)
+
+	var record map[string]any
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "report created", record["msg"])
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.Equal(t, "[REDACTED]", record["name"])
+	assert.Equal(t, "[REDACTED]", record["contact"])
+	assert.Equal(t, "[REDACTED]", record["reporter"].(map[string]any)["reporter_contact"])
+	assert.Equal(t, "Medan", record["city"])
+	assert.NotContains(t, buf.String(), "08123456789")
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewWithWriter(&buf, config.LogConfig{Level: "warn"})
+
+	log.Info("hidden")
+	assert.Empty(t, buf.String())
+
+	log.Warn("shown")
+	assert.Contains(t, buf.String(), "shown")
+}
+
+func TestRequestIDGenerated(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotEmpty(t, recorder.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRequestIDPropagated(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set(middleware.RequestIDHeader, "trace-abc-123")
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "trace-abc-123", recorder.Header().Get(middleware.RequestIDHeader))
+
+	// ID yang tidak valid diganti
+	req = httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set(middleware.RequestIDHeader, "bad id\n")
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.NotEqual(t, "bad id\n", recorder.Header().Get(middleware.RequestIDHeader))
+	assert.NotEmpty(t, recorder.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRequestIDStoredInJob(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      model.Ready,
+		ReporterID:       &reporter.ID,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"reason":"Sudah ditemukan keluarga"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+	req.Header.Set(middleware.RequestIDHeader, "delete-req-1")
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== job membawa request ID untuk log worker =====
+	var job model.Job
+	err := testDB.Where("ordering_key = ? AND kind = ?", model.ImageJobKey(model.SourceMissingPersons, missingPerson.ID), model.JobImageDelete).
+		First(&job).Error
+	assert.Nil(t, err)
+	assert.Equal(t, "delete-req-1", job.RequestID)
+}
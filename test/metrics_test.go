@@ -0,0 +1,69 @@
+package test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== request yang akan dihitung =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== scrape =====
+	req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	body, _ := io.ReadAll(recorder.Body)
+	assert.Contains(t, string(body), `missing_person_http_requests_total{method="GET",route="/api/v1/missing-persons",status="200"}`)
+	assert.Contains(t, string(body), `missing_person_http_request_duration_seconds_bucket{method="GET",route="/api/v1/missing-persons"`)
+	assert.Contains(t, string(body), `missing_person_db_query_duration_seconds_bucket{operation="query",table="missing_persons"`)
+}
+
+func TestMetricsQueueDepth(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.png",
+		ImageStatus:      model.Failed,
+		ModerationStatus: model.Approved,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(metrics.NewQueueCollector(repository.NewJobRepository(testDB).Stats))
+
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+
+	depth := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "missing_person_image_queue_depth" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			depth[metric.GetLabel()[0].GetValue()] = metric.GetGauge().GetValue()
+		}
+	}
+
+	assert.Equal(t, float64(1), depth["failed"])
+	assert.Equal(t, float64(0), depth["pending"])
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/migration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationSchemaIsCurrent(t *testing.T) {
+	migrator, err := migration.NewMigrator(testDB)
+	assert.Nil(t, err)
+
+	assert.Nil(t, migrator.Check(context.Background()))
+
+	statuses, err := migrator.Status(context.Background())
+	assert.Nil(t, err)
+	assert.NotEmpty(t, statuses)
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt, status.Name)
+	}
+}
+
+func TestMigrationDownAndUp(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := migration.NewMigrator(testDB)
+	assert.Nil(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	assert.Nil(t, err)
+	last := statuses[len(statuses)-1]
+
+	// ===== rollback migrasi terakhir =====
+	reverted, err := migrator.Down(ctx, 1)
+	assert.Nil(t, err)
+	assert.Len(t, reverted, 1)
+	assert.Equal(t, last.Version, reverted[0].Version)
+
+	err = migrator.Check(ctx)
+	assert.True(t, errors.Is(err, migration.ErrSchemaBehind))
+
+	// ===== apply lagi =====
+	applied, err := migrator.Up(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, last.Version, applied[0].Version)
+
+	assert.Nil(t, migrator.Check(ctx))
+
+	// tidak ada yang tersisa
+	applied, err = migrator.Up(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestMigrationBaseline(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := migration.NewMigrator(testDB)
+	assert.Nil(t, err)
+
+	statuses, err := migrator.Status(ctx)
+	assert.Nil(t, err)
+	last := statuses[len(statuses)-1]
+
+	// ===== schema dibuat di luar migrator =====
+	_, err = migrator.Down(ctx, 1)
+	assert.Nil(t, err)
+	assert.Nil(t, testDB.Exec(last.Up).Error)
+
+	marked, err := migrator.Baseline(ctx, last.Version)
+	assert.Nil(t, err)
+	assert.Len(t, marked, 1)
+	assert.Equal(t, last.Version, marked[0].Version)
+
+	// migrasi tidak dijalankan lagi
+	applied, err := migrator.Up(ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, applied)
+	assert.Nil(t, migrator.Check(ctx))
+
+	// ===== versi yang tidak dikenal =====
+	_, err = migrator.Baseline(ctx, 1)
+	assert.NotNil(t, err)
+
+	// nama tabel golang-migrate tetap kosong
+	var exists bool
+	assert.Nil(t, testDB.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error)
+	assert.False(t, exists)
+}
+
+func TestMigrationCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	up, down, err := migration.Create(dir, "Add Note Field", time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.FileExists(t, up)
+	assert.FileExists(t, down)
+
+	migrations, err := migration.Parse(os.DirFS(dir))
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, int64(20261018210000), migrations[0].Version)
+	assert.Equal(t, "add_note_field", migrations[0].Name)
+
+	_, _, err = migration.Create(dir, "drop; table", time.Now())
+	assert.NotNil(t, err)
+}
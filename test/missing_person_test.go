@@ -2,90 +2,230 @@ package test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"image"
+	"image/png"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Mhbib34/missing-person-service/internal/config"
 	"github.com/Mhbib34/missing-person-service/internal/controller"
 	"github.com/Mhbib34/missing-person-service/internal/entity"
+	"github.com/Mhbib34/missing-person-service/internal/helper"
+	"github.com/Mhbib34/missing-person-service/internal/metrics"
 	"github.com/Mhbib34/missing-person-service/internal/middleware"
+	"github.com/Mhbib34/missing-person-service/internal/migration"
 	"github.com/Mhbib34/missing-person-service/internal/model"
 	"github.com/Mhbib34/missing-person-service/internal/repository"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
 	"github.com/Mhbib34/missing-person-service/internal/usecase"
+	"github.com/Mhbib34/missing-person-service/internal/validation"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 var (
-	testDB     *gorm.DB
-	testRouter http.Handler
+	testDB      *gorm.DB
+	testRouter  http.Handler
+	testTokens  *helper.TokenManager
+	testStaging *upload.Staging
 )
 
+func newTestConfig() *config.Config {
+	return &config.Config{
+		Auth: config.AuthConfig{
+			JWTSecret: "test-secret",
+			JWTTTL:    time.Hour,
+		},
+		Upload: config.UploadConfig{
+			MaxSizeMB:  5,
+			StagingDir: filepath.Join(os.TempDir(), "missing-person-test-staging"),
+		},
+	}
+}
+
+const testDSN = "host=localhost user=postgres password=habib123 dbname=missing_person_test port=5432 sslmode=disable TimeZone=Asia/Jakarta"
+
 func setupTestDB() *gorm.DB {
-	dsn := "host=localhost user=postgres password=habib123 dbname=missing_person_test port=5432 sslmode=disable TimeZone=Asia/Jakarta"
+	db, err := gorm.Open(postgres.Open(testDSN), &gorm.Config{})
+	if err != nil {
+		panic(err)
+	}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// schema dibangun dari file migrasi yang sama dengan production
+	err = db.Exec("DROP SCHEMA public CASCADE; CREATE SCHEMA public").Error
 	if err != nil {
 		panic(err)
 	}
 
-	err = db.AutoMigrate(&model.MissingPersons{})
+	migrator, err := migration.NewMigrator(db)
 	if err != nil {
 		panic(err)
 	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		panic(err)
+	}
+
+	if err := metrics.InstrumentGorm(db); err != nil {
+		panic(err)
+	}
+	if err := tracing.InstrumentGorm(db); err != nil {
+		panic(err)
+	}
 
 	return db
 }
 
-func setupRouter(db *gorm.DB) http.Handler {
-	validate := validator.New()
+func setupRouter(db *gorm.DB, cfg *config.Config) http.Handler {
+	validate, err := validation.New()
+	if err != nil {
+		panic(err)
+	}
+	uploader := upload.NewUploader(cfg, testStaging)
+	tokens := helper.NewTokenManager(cfg)
 
+	userRepo := repository.NewUserRepository(db)
+	userUsecase := usecase.NewUserUsecase(userRepo, validate, tokens)
+	userController := controller.NewUserController(userUsecase)
 	repo := repository.NewMissingPersonRepository(db)
-	usecase := usecase.NewMissingPersonUsecase(repo, validate)
+	sightingRepo := repository.NewSightingRepository(db)
+	sightingUsecase := usecase.NewSightingUsecase(sightingRepo, repo, validate, uploader)
+	sightingController := controller.NewSightingController(sightingUsecase)
+	jobRepo := repository.NewJobRepository(db)
+	jobUsecase := usecase.NewJobUsecase(jobRepo)
+	jobController := controller.NewJobController(jobUsecase)
+	imageStorage, err := storage.NewLocalStorage(filepath.Join(os.TempDir(), "missing-person-test-images"), "http://localhost:3000/images")
+	if err != nil {
+		panic(err)
+	}
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		panic(err)
+	}
+	healthUsecase := usecase.NewHealthUsecase(db, imageStorage, testStaging, migrator)
+	healthController := controller.NewHealthController(healthUsecase)
+	usecase := usecase.NewMissingPersonUsecase(repo, validate, uploader)
 	controller := controller.NewMissingPersonController(usecase)
 
 	r := gin.New()
 
 	// middleware
-	r.Use(gin.Logger())
+	r.Use(middleware.Tracing())
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Logger())
+	r.Use(middleware.Metrics())
 	r.Use(middleware.ErrorRecovery()) // ⬅️ penting
-	
-	api := r.Group("/api/v1")
+
+	r.GET("/healthz", healthController.Liveness)
+	r.GET("/readyz", healthController.Readiness)
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	api := r.Group("/api/v1", middleware.Authenticate(tokens))
 	{
-		api.POST("/missing-persons", controller.Create)
+		api.POST("/auth/register", userController.Register)
+		api.POST("/auth/login", userController.Login)
+
+		api.POST("/missing-persons", middleware.RequireRole(), middleware.LimitBodySize(uploader.MaxBodySize), controller.Create)
+		api.GET("/missing-persons/nearby", controller.FindNearby)
 		api.GET("/missing-persons/:id", controller.FindByID)
 		api.GET("/missing-persons", controller.GetAll)
+		api.PATCH("/missing-persons/:id", middleware.RequireRole(), controller.Update)
+		api.DELETE("/missing-persons/:id", middleware.RequireRole(), controller.Delete)
+
+		api.POST("/missing-persons/:id/sightings", middleware.LimitBodySize(uploader.MaxBodySize), sightingController.Create)
+		api.GET("/missing-persons/:id/sightings", sightingController.FindByMissingPersonID)
+	}
+
+	moderation := api.Group("/moderation", middleware.RequireRole(model.RoleModerator, model.RoleAdmin))
+	{
+		moderation.GET("/queue", controller.GetModerationQueue)
+		moderation.POST("/missing-persons/:id/approve", controller.Approve)
+		moderation.POST("/missing-persons/:id/reject", controller.Reject)
+	}
+
+	admin := api.Group("/admin", middleware.RequireRole(model.RoleAdmin))
+	{
+		admin.POST("/missing-persons/:id/restore", controller.Restore)
+		admin.PATCH("/users/:id/role", userController.UpdateRole)
+		admin.GET("/jobs", jobController.GetAll)
+		admin.POST("/jobs/:id/requeue", jobController.Requeue)
+		admin.GET("/worker/status", jobController.WorkerStatus)
 	}
 
 	return r
 }
 
 func truncateMissingPersons(db *gorm.DB) {
-	db.Exec("TRUNCATE TABLE missing_persons")
+	db.Exec("TRUNCATE TABLE missing_persons, jobs CASCADE")
+}
+
+func truncateUsers(db *gorm.DB) {
+	db.Exec("TRUNCATE TABLE users CASCADE")
+}
+
+// createUserWithToken inserts a user with the given role and signs a token
+// for it.
+func createUserWithToken(t *testing.T, role model.Role) (model.User, string) {
+	user := model.User{
+		Name:         "User " + string(role),
+		Email:        uuid.NewString() + "@example.com",
+		PasswordHash: "-",
+		Role:         role,
+	}
+
+	err := testDB.Create(&user).Error
+	assert.Nil(t, err)
+
+	token, _, err := testTokens.GenerateToken(user)
+	assert.Nil(t, err)
+
+	return user, "Bearer " + token
+}
+
+// testPNG encodes a blank PNG image of the given size
+func testPNG(t *testing.T, width int, height int) []byte {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	assert.Nil(t, err)
+	return buf.Bytes()
 }
 
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
+	cfg := newTestConfig()
+	testTokens = helper.NewTokenManager(cfg)
+
+	staging, err := upload.NewStaging(cfg.Upload.StagingDir)
+	if err != nil {
+		panic(err)
+	}
+	testStaging = staging
 
 	testDB = setupTestDB()
-	testRouter = setupRouter(testDB)
+	testRouter = setupRouter(testDB, cfg)
 
 	code := m.Run()
 
 	os.Exit(code)
 }
 
-
 func TestCreateMissingPersonSuccess(t *testing.T) {
 	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
 
 	// ===== multipart body =====
 	body := &bytes.Buffer{}
@@ -98,12 +238,12 @@ func TestCreateMissingPersonSuccess(t *testing.T) {
 	_ = writer.WriteField("last_seen", "Medan")
 	_ = writer.WriteField("contact", "08123456789")
 
-	// fake image file
+	// image file
 	fileWriter, _ := writer.CreateFormFile(
 		"photo",
 		"test-image.jpg",
 	)
-	fileWriter.Write([]byte("FAKE_IMAGE_CONTENT"))
+	fileWriter.Write(testPNG(t, 100, 100))
 
 	writer.Close()
 
@@ -114,6 +254,7 @@ func TestCreateMissingPersonSuccess(t *testing.T) {
 		body,
 	)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", token)
 
 	recorder := httptest.NewRecorder()
 	testRouter.ServeHTTP(recorder, req)
@@ -134,15 +275,37 @@ func TestCreateMissingPersonSuccess(t *testing.T) {
 	assert.Equal(t, "Joko", data["name"])
 	assert.Equal(t, float64(63), data["age"])
 	assert.Equal(t, "pending", data["image_status"])
-	assert.Equal(t, "test-image.jpg", data["photo_id"])
+	assert.Nil(t, data["photos"])
+	assert.Equal(t, reporter.ID.String(), data["reporter_id"])
 
 	// ===== assert DB =====
 	var count int64
 	testDB.Model(&entity.MissingPersons{}).Count(&count)
 	assert.Equal(t, int64(1), count)
+
+	// ===== upload stored under a server generated name =====
+	var created model.MissingPersons
+	testDB.First(&created)
+	assert.NotEqual(t, "test-image.jpg", created.PhotoID)
+	assert.True(t, strings.HasSuffix(created.PhotoID, ".png"))
+
+	_, err := os.Stat(testStaging.Path(created.PhotoID))
+	assert.Nil(t, err)
+
+	// ===== job enqueued with the row =====
+	var job model.Job
+	err = testDB.First(&job, "ordering_key = ?", model.ImageJobKey(model.SourceMissingPersons, created.ID)).Error
+	assert.Nil(t, err)
+	assert.Equal(t, model.JobImageProcess, job.Kind)
+	assert.Equal(t, model.JobPending, job.Status)
+
+	var payload model.ImageJobPayload
+	assert.Nil(t, json.Unmarshal(job.Payload, &payload))
+	assert.Equal(t, created.PhotoID, payload.PhotoID)
 }
 func TestCreateMissingPersonFailedBadRequest(t *testing.T) {
 	truncateMissingPersons(testDB)
+	_, token := createUserWithToken(t, model.RoleReporter)
 
 	// ===== multipart body =====
 	body := &bytes.Buffer{}
@@ -155,12 +318,12 @@ func TestCreateMissingPersonFailedBadRequest(t *testing.T) {
 	_ = writer.WriteField("last_seen", "Medan")
 	_ = writer.WriteField("contact", "08123456789")
 
-	// fake image file
+	// image file
 	fileWriter, _ := writer.CreateFormFile(
 		"photo",
 		"test-image.jpg",
 	)
-	fileWriter.Write([]byte("FAKE_IMAGE_CONTENT"))
+	fileWriter.Write(testPNG(t, 100, 100))
 
 	writer.Close()
 
@@ -171,6 +334,7 @@ func TestCreateMissingPersonFailedBadRequest(t *testing.T) {
 		body,
 	)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", token)
 
 	recorder := httptest.NewRecorder()
 	testRouter.ServeHTTP(recorder, req)
@@ -184,21 +348,29 @@ func TestCreateMissingPersonFailedBadRequest(t *testing.T) {
 	var response map[string]any
 	json.Unmarshal(respBody, &response)
 
-	assert.Equal(t, "BAD REQUEST", response["status"])
+	assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "/problems/validation-error", response["type"])
+	assert.Equal(t, float64(http.StatusBadRequest), response["status"])
+	assert.Equal(t, "/api/v1/missing-persons", response["instance"])
+	assert.Equal(t, []any{map[string]any{
+		"field":   "name",
+		"rule":    "required",
+		"message": "name is a required field",
+	}}, response["errors"])
 }
 
-
 func TestGetMissingPersonByIdSuccess(t *testing.T) {
 	truncateMissingPersons(testDB)
 
 	// ===== create data via GORM (UUID auto) =====
 	missingPerson := model.MissingPersons{
-		Name:        "Joko",
-		Age:         63,
-		Description: "celana pendek",
-		LastSeen:    "Medan",
-		Contact:     "08123456789",
-		PhotoID:     "test-image.jpg",
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
 	}
 
 	err := testDB.Create(&missingPerson).Error
@@ -237,19 +409,86 @@ func TestGetMissingPersonByIdSuccess(t *testing.T) {
 	assert.Equal(t, "Medan", data["last_seen"])
 	assert.Equal(t, "08123456789", data["contact"])
 	assert.Equal(t, "pending", data["image_status"])
-	assert.Equal(t, "test-image.jpg", data["photo_id"])
+	assert.NotContains(t, data, "photo_id")
+}
+
+func TestGetMissingPersonByIdHidesModerationInfo(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, reporterToken := createUserWithToken(t, model.RoleReporter)
+	moderator, _ := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	moderatedAt := time.Now()
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ReporterID:       &reporter.ID,
+		ModerationStatus: model.Approved,
+		ModeratedBy:      &moderator.ID,
+		ModeratedAt:      &moderatedAt,
+		RetractedBy:      &moderator.ID,
+		RetractionReason: "laporan ganda",
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	get := func(path string, token string) []map[string]any {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", token)
+		}
+		recorder := httptest.NewRecorder()
+		testRouter.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response map[string]any
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		if item, ok := response["data"].(map[string]any); ok {
+			return []map[string]any{item}
+		}
+		var items []map[string]any
+		for _, item := range response["data"].([]any) {
+			items = append(items, item.(map[string]any))
+		}
+		return items
+	}
+
+	// ===== publik: detail dan list =====
+	items := append(
+		get("/api/v1/missing-persons/"+missingPerson.ID.String(), ""),
+		get("/api/v1/missing-persons", "")...,
+	)
+	assert.Len(t, items, 2)
+	for _, item := range items {
+		assert.Equal(t, "Joko", item["name"])
+		for _, field := range []string{"reporter_id", "moderated_by", "moderated_at", "rejection_reason", "retracted_by", "retraction_reason"} {
+			assert.NotContains(t, item, field)
+		}
+	}
+
+	// ===== pelapor melihat laporannya sendiri =====
+	items = get("/api/v1/missing-persons/"+missingPerson.ID.String(), reporterToken)
+	assert.Equal(t, reporter.ID.String(), items[0]["reporter_id"])
+	assert.NotContains(t, items[0], "moderated_by")
 }
 func TestGetMissingPersonByIdFailedIfNotFound(t *testing.T) {
 	truncateMissingPersons(testDB)
 
 	// ===== create data via GORM (UUID auto) =====
 	missingPerson := model.MissingPersons{
-		Name:        "Joko",
-		Age:         63,
-		Description: "celana pendek",
-		LastSeen:    "Medan",
-		Contact:     "08123456789",
-		PhotoID:     "test-image.jpg",
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
 	}
 
 	err := testDB.Create(&missingPerson).Error
@@ -277,23 +516,28 @@ func TestGetMissingPersonByIdFailedIfNotFound(t *testing.T) {
 	var response map[string]any
 	_ = json.Unmarshal(respBody, &response)
 
-	assert.Equal(t, "NOT FOUND", response["status"])
-	assert.Equal(t, "Report not found", response["error"])
+	assert.Equal(t, float64(http.StatusNotFound), response["status"])
+	assert.Equal(t, "Report not found", response["detail"])
 }
 
-
 func TestListMissingPersonSuccess(t *testing.T) {
 	truncateMissingPersons(testDB)
 
 	// ===== create data via GORM (UUID auto) =====
 	missingPerson := model.MissingPersons{
-		Name:        "Joko",
-		Age:         63,
-		Description: "celana pendek",
-		LastSeen:    "Medan",
-		Contact:     "08123456789",
-		PhotoID:     "test-image.jpg",
-		ImageStatus: "ready",
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+		Photos: model.Photos{
+			Thumbnail: "http://localhost:3000/images/joko/thumbnail",
+			Card:      "http://localhost:3000/images/joko/card",
+			Full:      "http://localhost:3000/images/joko/full",
+		},
 	}
 
 	err := testDB.Create(&missingPerson).Error
@@ -333,7 +577,10 @@ func TestListMissingPersonSuccess(t *testing.T) {
 	assert.Equal(t, "Medan", item["last_seen"])
 	assert.Equal(t, "08123456789", item["contact"])
 	assert.Equal(t, "ready", item["image_status"])
-	assert.Equal(t, "test-image.jpg", item["photo_id"])
+
+	photos := item["photos"].(map[string]any)
+	assert.Equal(t, "http://localhost:3000/images/joko/card", photos["card"])
+	assert.NotContains(t, item, "photo_id")
 }
 
 func TestListMissingPersonSuccessWithPagination(t *testing.T) {
@@ -341,13 +588,14 @@ func TestListMissingPersonSuccessWithPagination(t *testing.T) {
 
 	// ===== create data via GORM (UUID auto) =====
 	missingPerson := model.MissingPersons{
-		Name:        "Joko",
-		Age:         63,
-		Description: "celana pendek",
-		LastSeen:    "Medan",
-		Contact:     "08123456789",
-		PhotoID:     "test-image.jpg",
-		ImageStatus: "ready",
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
 	}
 
 	err := testDB.Create(&missingPerson).Error
@@ -376,4 +624,821 @@ func TestListMissingPersonSuccessWithPagination(t *testing.T) {
 
 	data := response["data"].([]any)
 	assert.Len(t, data, 0)
-}
\ No newline at end of file
+}
+
+func TestListMissingPersonExcludesClosedReports(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== create data via GORM (UUID auto) =====
+	openPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+	closedPerson := model.MissingPersons{
+		Name:             "Budi",
+		Age:              25,
+		Description:      "kaos merah",
+		LastSeen:         "Jakarta",
+		Contact:          "08123456780",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+		CaseStatus:       model.FoundSafe,
+	}
+
+	assert.Nil(t, testDB.Create(&openPerson).Error)
+	assert.Nil(t, testDB.Create(&closedPerson).Error)
+
+	// ===== request GET (default) =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 1)
+	assert.Equal(t, openPerson.ID.String(), data[0].(map[string]any)["id"])
+
+	// ===== request GET (explicit filter) =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?case_status=found_safe", nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	response = map[string]any{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data = response["data"].([]any)
+	assert.Len(t, data, 1)
+	assert.Equal(t, closedPerson.ID.String(), data[0].(map[string]any)["id"])
+
+	// ===== found reports stay retrievable by ID =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+closedPerson.ID.String(), nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestUpdateMissingPersonSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ReporterID:       &reporter.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== request PATCH =====
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"description":"celana pendek, topi hitam","case_status":"found_safe"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	resp := recorder.Result()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	var response map[string]any
+	_ = json.Unmarshal(respBody, &response)
+
+	assert.Equal(t, "OK", response["status"])
+
+	data := response["data"].(map[string]any)
+
+	assert.Equal(t, "Joko", data["name"])
+	assert.Equal(t, "celana pendek, topi hitam", data["description"])
+	assert.Equal(t, "found_safe", data["case_status"])
+
+	// ===== assert DB =====
+	var updated model.MissingPersons
+	testDB.First(&updated, "id = ?", missingPerson.ID)
+	assert.Equal(t, model.FoundSafe, updated.CaseStatus)
+	assert.Equal(t, "test-image.jpg", updated.PhotoID)
+	// deskripsi diubah pelapor, laporan direview ulang
+	assert.Equal(t, model.PendingReview, updated.ModerationStatus)
+}
+
+func TestUpdateMissingPersonFailedInvalidTransition(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, token := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		CaseStatus:       model.Closed,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== request PATCH =====
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"case_status":"open"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	assert.Equal(t, float64(http.StatusConflict), response["status"])
+}
+
+func TestUpdateMissingPersonFailedBadRequest(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, token := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== request PATCH =====
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"case_status":"missing"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestDeleteMissingPersonSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+		ReporterID:       &reporter.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== sighting dengan dan tanpa foto =====
+	withPhoto := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-time.Hour),
+		Location:        "Terminal Amplas",
+		Description:     "duduk di ruang tunggu",
+		ReporterContact: "08129999999",
+		PhotoID:         "sighting-image.jpg",
+		ImageStatus:     model.Ready,
+	}
+	withoutPhoto := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-2 * time.Hour),
+		Location:        "Pasar Petisah",
+		Description:     "berjalan ke arah utara",
+		ReporterContact: "08128888888",
+	}
+	assert.Nil(t, testDB.Create(&withPhoto).Error)
+	assert.Nil(t, testDB.Create(&withoutPhoto).Error)
+
+	// ===== request DELETE =====
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"reason":"Sudah ditemukan keluarga"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== report is gone from the API =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// ===== assert DB: soft deleted with audit reason =====
+	var deleted model.MissingPersons
+	err = testDB.Unscoped().First(&deleted, "id = ?", missingPerson.ID).Error
+	assert.Nil(t, err)
+	assert.True(t, deleted.DeletedAt.Valid)
+	assert.Equal(t, &reporter.ID, deleted.RetractedBy)
+	assert.Equal(t, "Sudah ditemukan keluarga", deleted.RetractionReason)
+
+	// ===== photo removal enqueued =====
+	var count int64
+	testDB.Model(&model.Job{}).
+		Where("ordering_key = ? AND kind = ?", model.ImageJobKey(model.SourceMissingPersons, missingPerson.ID), model.JobImageDelete).
+		Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// ===== sighting photo removal enqueued too =====
+	var sightingJob model.Job
+	err = testDB.Where("ordering_key = ? AND kind = ?", model.ImageJobKey(model.SourceSightings, withPhoto.ID), model.JobImageDelete).
+		First(&sightingJob).Error
+	assert.Nil(t, err)
+
+	var payload model.ImageJobPayload
+	assert.Nil(t, json.Unmarshal(sightingJob.Payload, &payload))
+	assert.Equal(t, model.SourceSightings, payload.SourceTable)
+	assert.Equal(t, "sighting-image.jpg", payload.PhotoID)
+
+	testDB.Model(&model.Job{}).
+		Where("ordering_key = ?", model.ImageJobKey(model.SourceSightings, withoutPhoto.ID)).
+		Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestDeleteMissingPersonFailedBadRequest(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ReporterID:       &reporter.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== request DELETE without reason =====
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestRestoreMissingPersonSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, reporterToken := createUserWithToken(t, model.RoleReporter)
+	admin, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	// ===== create soft deleted data via GORM =====
+	retractedBy := uuid.New()
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		RetractedBy:      &retractedBy,
+		RetractionReason: "Salah input",
+	}
+
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+	assert.Nil(t, testDB.Delete(&missingPerson).Error)
+	deleteJob := model.NewImageJob(model.JobImageDelete, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	assert.Nil(t, testDB.Create(&deleteJob).Error)
+
+	sighting := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-time.Hour),
+		Location:        "Terminal Amplas",
+		Description:     "duduk di ruang tunggu",
+		ReporterContact: "08129999999",
+		PhotoID:         "sighting-image.jpg",
+		ImageStatus:     model.Ready,
+	}
+	assert.Nil(t, testDB.Create(&sighting).Error)
+	sightingDeleteJob := model.NewImageJob(model.JobImageDelete, model.SourceSightings, sighting.ID, sighting.PhotoID)
+	assert.Nil(t, testDB.Create(&sightingDeleteJob).Error)
+
+	// ===== request without token =====
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/missing-persons/"+missingPerson.ID.String()+"/restore", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// ===== request as reporter =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/missing-persons/"+missingPerson.ID.String()+"/restore", nil)
+	req.Header.Set("Authorization", reporterToken)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// ===== request as admin =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/missing-persons/"+missingPerson.ID.String()+"/restore", nil)
+	req.Header.Set("Authorization", adminToken)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== report is visible again =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== pending photo removal cancelled =====
+	var count int64
+	testDB.Model(&model.Job{}).Where("ordering_key = ?", model.ImageJobKey(model.SourceMissingPersons, missingPerson.ID)).Count(&count)
+	assert.Equal(t, int64(0), count)
+	testDB.Model(&model.Job{}).Where("ordering_key = ?", model.ImageJobKey(model.SourceSightings, sighting.ID)).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	// ===== retraction kept, restore recorded =====
+	var restored model.MissingPersons
+	assert.Nil(t, testDB.First(&restored, "id = ?", missingPerson.ID).Error)
+	assert.Equal(t, &retractedBy, restored.RetractedBy)
+	assert.Equal(t, "Salah input", restored.RetractionReason)
+	assert.Equal(t, &admin.ID, restored.RestoredBy)
+	assert.NotNil(t, restored.RestoredAt)
+}
+
+func TestRestoreMissingPersonFailedPhotosDeleted(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, adminToken := createUserWithToken(t, model.RoleAdmin)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      model.Deleting,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+	assert.Nil(t, testDB.Delete(&missingPerson).Error)
+
+	// ===== worker sudah mulai menghapus foto =====
+	deleteJob := model.NewImageJob(model.JobImageDelete, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	deleteJob.Status = model.JobProcessing
+	assert.Nil(t, testDB.Create(&deleteJob).Error)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/missing-persons/"+missingPerson.ID.String()+"/restore", nil)
+	req.Header.Set("Authorization", adminToken)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+
+	// ===== report stays retracted, job untouched =====
+	var count int64
+	testDB.Model(&model.MissingPersons{}).Where("id = ?", missingPerson.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	var job model.Job
+	assert.Nil(t, testDB.First(&job, "id = ?", deleteJob.ID).Error)
+	assert.Equal(t, model.JobProcessing, job.Status)
+}
+
+func TestListMissingPersonWithSearchAndFilters(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== create data via GORM (UUID auto) =====
+	joko := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek, topi hitam",
+		LastSeen:         "Pasar Petisah Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+	budi := model.MissingPersons{
+		Name:             "Budi",
+		Age:              25,
+		Description:      "kaos merah",
+		LastSeen:         "Medan",
+		Contact:          "08123456780",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+	siti := model.MissingPersons{
+		Name:             "Siti",
+		Age:              30,
+		Description:      "jilbab biru",
+		LastSeen:         "Jakarta",
+		Contact:          "08123456781",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+
+	assert.Nil(t, testDB.Create(&joko).Error)
+	assert.Nil(t, testDB.Create(&budi).Error)
+	assert.Nil(t, testDB.Create(&siti).Error)
+
+	// ===== full-text search + age range =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?q=medan&min_age=40&max_age=70", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 1)
+	assert.Equal(t, joko.ID.String(), data[0].(map[string]any)["id"])
+
+	// ===== applied filters are echoed =====
+	pagination := response["pagination"].(map[string]any)
+	filters := pagination["filters"].(map[string]any)
+
+	assert.Equal(t, "medan", filters["q"])
+	assert.Equal(t, float64(40), filters["min_age"])
+	assert.Equal(t, float64(70), filters["max_age"])
+	assert.Equal(t, "relevance", filters["sort"])
+	assert.Equal(t, "desc", filters["order"])
+
+	// ===== sort by age ascending =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?sort=age&order=asc", nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	response = map[string]any{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data = response["data"].([]any)
+	assert.Len(t, data, 3)
+	assert.Equal(t, budi.ID.String(), data[0].(map[string]any)["id"])
+	assert.Equal(t, siti.ID.String(), data[1].(map[string]any)["id"])
+	assert.Equal(t, joko.ID.String(), data[2].(map[string]any)["id"])
+
+	// ===== created_at range in the past matches nothing =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?created_from=2000-01-01&created_to=2000-12-31", nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	response = map[string]any{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	assert.Len(t, response["data"].([]any), 0)
+}
+
+func TestListMissingPersonFailedInvalidFilter(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== max_age below min_age =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?min_age=40&max_age=20", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// ===== unknown sort field =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons?sort=contact", nil)
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestFindNearbyMissingPersonSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== create data via GORM (UUID auto) =====
+	// Lapangan Merdeka Medan, Pasar Petisah (~2 km) dan Monas Jakarta (~1400 km)
+	merdeka := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Lapangan Merdeka",
+		Latitude:         ptr(3.5911),
+		Longitude:        ptr(98.6779),
+		City:             "Medan",
+		Province:         "Sumatera Utara",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+	petisah := model.MissingPersons{
+		Name:             "Budi",
+		Age:              25,
+		Description:      "kaos merah",
+		LastSeen:         "Pasar Petisah",
+		Latitude:         ptr(3.5897),
+		Longitude:        ptr(98.6625),
+		City:             "Medan",
+		Province:         "Sumatera Utara",
+		Contact:          "08123456780",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+	monas := model.MissingPersons{
+		Name:             "Siti",
+		Age:              30,
+		Description:      "jilbab biru",
+		LastSeen:         "Monas",
+		Latitude:         ptr(-6.1754),
+		Longitude:        ptr(106.8272),
+		City:             "Jakarta Pusat",
+		Province:         "DKI Jakarta",
+		Contact:          "08123456781",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+
+	assert.Nil(t, testDB.Create(&merdeka).Error)
+	assert.Nil(t, testDB.Create(&petisah).Error)
+	assert.Nil(t, testDB.Create(&monas).Error)
+
+	// ===== request GET nearby =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/nearby?lat=3.5952&lng=98.6722&radius_km=10", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 2)
+
+	first := data[0].(map[string]any)
+	second := data[1].(map[string]any)
+
+	assert.Equal(t, merdeka.ID.String(), first["id"])
+	assert.Equal(t, petisah.ID.String(), second["id"])
+	assert.Less(t, first["distance_km"].(float64), second["distance_km"].(float64))
+	assert.Less(t, second["distance_km"].(float64), float64(10))
+}
+
+func TestFindNearbyMissingPersonFailedBadRequest(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== missing lng =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/nearby?lat=3.5952", nil)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCreateMissingPersonFailedUnauthorized(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== multipart body =====
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("name", "Joko")
+	_ = writer.WriteField("age", "63")
+	_ = writer.WriteField("description", "celana pendek")
+	_ = writer.WriteField("last_seen", "Medan")
+	_ = writer.WriteField("contact", "08123456789")
+
+	writer.Close()
+
+	// ===== request without token =====
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/missing-persons", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+
+	// ===== request with invalid token =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons", nil)
+	req.Header.Set("Authorization", "Bearer not-a-jwt")
+
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+}
+
+func TestUpdateMissingPersonFailedNotOwner(t *testing.T) {
+	truncateMissingPersons(testDB)
+	owner, _ := createUserWithToken(t, model.RoleReporter)
+	_, otherToken := createUserWithToken(t, model.RoleReporter)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ReporterID:       &owner.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== request PATCH as another reporter =====
+	req := httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"name":"Bukan Joko"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", otherToken)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// ===== assert DB unchanged =====
+	var unchanged model.MissingPersons
+	testDB.First(&unchanged, "id = ?", missingPerson.ID)
+	assert.Equal(t, "Joko", unchanged.Name)
+}
+
+func TestCreateMissingPersonFailedInvalidImage(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, token := createUserWithToken(t, model.RoleReporter)
+
+	cases := map[string][]byte{
+		"not an image": []byte("<?php echo 'hello'; ?>"),
+		"too small":    testPNG(t, 10, 10),
+		// worker belum bisa decode HEIC
+		"heic": append([]byte{0, 0, 0, 24}, []byte("ftypheic\x00\x00\x00\x00mif1heic")...),
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			// ===== multipart body =====
+			body := &bytes.Buffer{}
+			writer := multipart.NewWriter(body)
+
+			_ = writer.WriteField("name", "Joko")
+			_ = writer.WriteField("age", "63")
+			_ = writer.WriteField("description", "celana pendek")
+			_ = writer.WriteField("last_seen", "Medan")
+			_ = writer.WriteField("contact", "08123456789")
+
+			// filename ends in .jpg but the content decides
+			fileWriter, _ := writer.CreateFormFile("photo", "../../evil.jpg")
+			fileWriter.Write(content)
+
+			writer.Close()
+
+			// ===== request =====
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/missing-persons", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req.Header.Set("Authorization", token)
+
+			recorder := httptest.NewRecorder()
+			testRouter.ServeHTTP(recorder, req)
+
+			// ===== assert response =====
+			assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var count int64
+			testDB.Model(&model.MissingPersons{}).Count(&count)
+			assert.Equal(t, int64(0), count)
+		})
+	}
+}
+
+func TestCreateMissingPersonFailedTooLarge(t *testing.T) {
+	truncateMissingPersons(testDB)
+	_, token := createUserWithToken(t, model.RoleReporter)
+
+	cfg := newTestConfig()
+	cfg.Upload.MaxSizeMB = 1
+	router := setupRouter(testDB, cfg)
+
+	// ===== multipart body larger than 1 MB + form overhead =====
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("name", "Joko")
+
+	fileWriter, _ := writer.CreateFormFile("photo", "big.png")
+	fileWriter.Write(testPNG(t, 100, 100))
+	fileWriter.Write(make([]byte, 3<<20))
+
+	writer.Close()
+
+	// ===== request =====
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/missing-persons", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", token)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	assert.Equal(t, float64(http.StatusRequestEntityTooLarge), response["status"])
+}
@@ -0,0 +1,235 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModerationApproveSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, reporterToken := createUserWithToken(t, model.RoleReporter)
+	_, moderatorToken := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:        "Joko",
+		Age:         63,
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     "test-image.jpg",
+		ImageStatus: "ready",
+		ReporterID:  &reporter.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== hidden from public list and detail =====
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons", nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.Len(t, response["data"], 0)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// ===== reporter still sees their own report =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+	req.Header.Set("Authorization", reporterToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== queue is for moderators only =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/moderation/queue", nil)
+	req.Header.Set("Authorization", reporterToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/moderation/queue", nil)
+	req.Header.Set("Authorization", moderatorToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	response = map[string]any{}
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 1)
+	assert.Equal(t, missingPerson.ID.String(), data[0].(map[string]any)["id"])
+
+	// ===== approve =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/moderation/missing-persons/"+missingPerson.ID.String()+"/approve", nil)
+	req.Header.Set("Authorization", moderatorToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== now public =====
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== approving twice conflicts =====
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/moderation/missing-persons/"+missingPerson.ID.String()+"/approve", nil)
+	req.Header.Set("Authorization", moderatorToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code)
+}
+
+func TestModerationRejectSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, reporterToken := createUserWithToken(t, model.RoleReporter)
+	moderator, moderatorToken := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	missingPerson := model.MissingPersons{
+		Name:        "Joko",
+		Age:         63,
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     "test-image.jpg",
+		ImageStatus: "ready",
+		ReporterID:  &reporter.ID,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	// ===== reject without reason =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/moderation/missing-persons/"+missingPerson.ID.String()+"/reject",
+		strings.NewReader(`{}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", moderatorToken)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	// ===== reject with reason =====
+	req = httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/moderation/missing-persons/"+missingPerson.ID.String()+"/reject",
+		strings.NewReader(`{"reason":"Foto tidak sesuai"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", moderatorToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var rejected model.MissingPersons
+	testDB.First(&rejected, "id = ?", missingPerson.ID)
+	assert.Equal(t, model.Rejected, rejected.ModerationStatus)
+	assert.Equal(t, "Foto tidak sesuai", rejected.RejectionReason)
+	assert.Equal(t, moderator.ID, *rejected.ModeratedBy)
+
+	// ===== reporter edit sends it back to the queue =====
+	req = httptest.NewRequest(
+		http.MethodPatch,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"description":"celana pendek, kaos biru"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", reporterToken)
+	recorder = httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var resubmitted model.MissingPersons
+	testDB.First(&resubmitted, "id = ?", missingPerson.ID)
+	assert.Equal(t, model.PendingReview, resubmitted.ModerationStatus)
+	assert.Empty(t, resubmitted.RejectionReason)
+	assert.Nil(t, resubmitted.ModeratedBy)
+	assert.Nil(t, resubmitted.ModeratedAt)
+}
+
+func TestModerationApprovedEditNeedsReview(t *testing.T) {
+	truncateMissingPersons(testDB)
+	reporter, reporterToken := createUserWithToken(t, model.RoleReporter)
+	moderator, moderatorToken := createUserWithToken(t, model.RoleModerator)
+
+	// ===== create data via GORM (UUID auto) =====
+	moderatedAt := time.Now()
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ImageStatus:      "ready",
+		ReporterID:       &reporter.ID,
+		ModerationStatus: model.Approved,
+		ModeratedBy:      &moderator.ID,
+		ModeratedAt:      &moderatedAt,
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	edit := func(token string, body string) model.MissingPersons {
+		req := httptest.NewRequest(
+			http.MethodPatch,
+			"/api/v1/missing-persons/"+missingPerson.ID.String(),
+			strings.NewReader(body),
+		)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", token)
+		recorder := httptest.NewRecorder()
+		testRouter.ServeHTTP(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var updated model.MissingPersons
+		testDB.First(&updated, "id = ?", missingPerson.ID)
+		return updated
+	}
+
+	// ===== moderator edit keeps it approved =====
+	updated := edit(moderatorToken, `{"city":"Medan"}`)
+	assert.Equal(t, model.Approved, updated.ModerationStatus)
+	assert.Equal(t, moderator.ID, *updated.ModeratedBy)
+
+	// ===== reporter edit takes it off the public list =====
+	updated = edit(reporterToken, `{"contact":"08120000000"}`)
+	assert.Equal(t, model.PendingReview, updated.ModerationStatus)
+	assert.Nil(t, updated.ModeratedBy)
+	assert.Nil(t, updated.ModeratedAt)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/missing-persons/"+missingPerson.ID.String(), nil)
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,227 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/database"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type testPayload struct {
+	N int `json:"n"`
+}
+
+func createTestJob(t *testing.T, kind string, n int, priority int, runAt time.Time) model.Job {
+	payload, err := json.Marshal(testPayload{N: n})
+	assert.Nil(t, err)
+
+	job := model.Job{
+		Kind:     kind,
+		Payload:  payload,
+		Priority: priority,
+		Status:   model.JobPending,
+		RunAt:    runAt,
+	}
+	assert.Nil(t, testDB.Create(&job).Error)
+	return job
+}
+
+// runQueue runs the queue until none of the given jobs is due or running
+func runQueue(t *testing.T, queue *worker.Queue, jobs ...model.Job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		queue.Start(ctx, 20*time.Millisecond)
+	}()
+
+	ids := make([]any, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+
+	assert.Eventually(t, func() bool {
+		var open int64
+		testDB.Model(&model.Job{}).
+			Where("id IN ? AND (status = ? OR (status = ? AND run_at <= NOW()))", ids, model.JobProcessing, model.JobPending).
+			Count(&open)
+		return open == 0
+	}, 5*time.Second, 20*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func TestQueueRunsJobsByPriority(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	var (
+		mu  sync.Mutex
+		ran []int
+	)
+
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.record", worker.Options{Concurrency: 1}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, payload.N)
+		return nil
+	})
+
+	now := time.Now().Add(-time.Second)
+	low := createTestJob(t, "test.record", 1, 0, now)
+	high := createTestJob(t, "test.record", 2, 10, now)
+	later := createTestJob(t, "test.record", 3, 100, time.Now().Add(time.Hour))
+
+	runQueue(t, queue, low, high)
+
+	// ===== higher priority first, scheduled job not yet =====
+	assert.Equal(t, []int{2, 1}, ran)
+
+	var job model.Job
+	assert.Nil(t, testDB.First(&job, "id = ?", low.ID).Error)
+	assert.Equal(t, model.JobDone, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+
+	assert.Nil(t, testDB.First(&job, "id = ?", later.ID).Error)
+	assert.Equal(t, model.JobPending, job.Status)
+}
+
+func TestQueueRetriesFailedJob(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.fail", worker.Options{MaxAttempts: 2}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		return errors.New("boom")
+	})
+
+	failing := createTestJob(t, "test.fail", 1, 0, time.Now().Add(-time.Second))
+	runQueue(t, queue, failing)
+
+	// ===== first failure is retried later =====
+	var job model.Job
+	assert.Nil(t, testDB.First(&job, "id = ?", failing.ID).Error)
+	assert.Equal(t, model.JobPending, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Equal(t, "boom", job.LastError)
+
+	var scheduled int64
+	testDB.Model(&model.Job{}).Where("id = ? AND run_at > NOW()", failing.ID).Count(&scheduled)
+	assert.Equal(t, int64(1), scheduled)
+
+	// ===== last attempt dead-letters the job =====
+	assert.Nil(t, testDB.Model(&job).Update("run_at", gorm.Expr("NOW() - INTERVAL '1 second'")).Error)
+	runQueue(t, queue, failing)
+
+	assert.Nil(t, testDB.First(&job, "id = ?", failing.ID).Error)
+	assert.Equal(t, model.JobFailed, job.Status)
+	assert.Equal(t, 2, job.Attempts)
+}
+
+func TestQueueWakesUpOnNotify(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	handled := make(chan int, 1)
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.notify", worker.Options{}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		handled <- payload.N
+		return nil
+	})
+
+	// poll terlalu lambat untuk test, hanya NOTIFY yang bisa membangunkan
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go queue.Start(ctx, time.Hour)
+	go worker.NewListener(testDSN, queue.Notify).Start(ctx)
+	time.Sleep(500 * time.Millisecond)
+
+	err := testDB.Transaction(func(tx *gorm.DB) error {
+		payload, _ := json.Marshal(testPayload{N: 7})
+		if err := tx.Create(&model.Job{Kind: "test.notify", Payload: payload, Status: model.JobPending}).Error; err != nil {
+			return err
+		}
+		return database.Notify(tx, model.JobsChannel, "test.notify")
+	})
+	assert.Nil(t, err)
+
+	select {
+	case n := <-handled:
+		assert.Equal(t, 7, n)
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not picked up after NOTIFY")
+	}
+}
+
+func TestQueueShutdownDrainsRunningJobs(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	started := make(chan struct{}, 2)
+	unblock := make(chan struct{})
+
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.slow", worker.Options{Concurrency: 2}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		started <- struct{}{}
+		<-unblock
+		return nil
+	})
+
+	startQueue := func() (context.CancelFunc, chan struct{}) {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			queue.Start(ctx, 20*time.Millisecond)
+		}()
+		return cancel, done
+	}
+
+	// ===== job yang sedang berjalan diselesaikan sebelum Start kembali =====
+	finished := createTestJob(t, "test.slow", 1, 0, time.Now().Add(-time.Second))
+	cancel, done := startQueue()
+	<-started
+	cancel()
+
+	select {
+	case <-done:
+		t.Fatal("queue stopped before the running job finished")
+	case <-time.After(200 * time.Millisecond):
+	}
+	unblock <- struct{}{}
+	<-done
+
+	var job model.Job
+	assert.Nil(t, testDB.First(&job, "id = ?", finished.ID).Error)
+	assert.Equal(t, model.JobDone, job.Status)
+	assert.Nil(t, job.LeaseExpiresAt)
+
+	// ===== shutdown timeout, job dikembalikan ke antrian =====
+	released := createTestJob(t, "test.slow", 2, 0, time.Now().Add(-time.Second))
+	cancel, done = startQueue()
+	<-started
+	cancel()
+
+	count, err := queue.Release(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Nil(t, testDB.First(&job, "id = ?", released.ID).Error)
+	assert.Equal(t, model.JobPending, job.Status)
+	assert.Nil(t, job.LeaseExpiresAt)
+	assert.Equal(t, 0, job.Attempts)
+	assert.Equal(t, "worker shut down", job.LastError)
+
+	// handler yang terlambat selesai tidak lagi memegang job
+	close(unblock)
+	<-done
+
+	assert.Nil(t, testDB.First(&job, "id = ?", released.ID).Error)
+	assert.Equal(t, model.JobPending, job.Status)
+}
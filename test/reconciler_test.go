@@ -0,0 +1,103 @@
+package test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/upload"
+	"github.com/Mhbib34/missing-person-service/internal/worker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilerRepairsOrphans(t *testing.T) {
+	truncateMissingPersons(testDB)
+	staging, err := upload.NewStaging(t.TempDir())
+	assert.Nil(t, err)
+
+	old := time.Now().Add(-2 * time.Hour)
+
+	// ===== pending row without job, staged file still there =====
+	stagedFile := uuid.NewString() + ".png"
+	assert.Nil(t, os.WriteFile(staging.Path(stagedFile), testPNG(t, 100, 100), 0644))
+
+	requeued := model.MissingPersons{
+		Name:        "Joko",
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     stagedFile,
+		ImageStatus: model.Pending,
+		CreatedAt:   old,
+	}
+	assert.Nil(t, testDB.Create(&requeued).Error)
+
+	// ===== pending row without job, staged file gone =====
+	lost := model.MissingPersons{
+		Name:        "Budi",
+		Description: "kaos merah",
+		LastSeen:    "Binjai",
+		Contact:     "08123456789",
+		PhotoID:     uuid.NewString() + ".png",
+		ImageStatus: model.Pending,
+		CreatedAt:   old,
+	}
+	assert.Nil(t, testDB.Create(&lost).Error)
+
+	// ===== staged file without any row =====
+	orphanFile := staging.Path(uuid.NewString() + ".png")
+	assert.Nil(t, os.WriteFile(orphanFile, testPNG(t, 100, 100), 0644))
+	assert.Nil(t, os.Chtimes(orphanFile, old, old))
+
+	// ===== reconcile =====
+	err = worker.NewReconciler(testDB, staging).Reconcile(context.Background())
+	assert.Nil(t, err)
+
+	var job model.Job
+	err = testDB.First(&job, "ordering_key = ?", model.ImageJobKey(model.SourceMissingPersons, requeued.ID)).Error
+	assert.Nil(t, err)
+	assert.Equal(t, model.JobImageProcess, job.Kind)
+
+	var failed model.MissingPersons
+	testDB.First(&failed, "id = ?", lost.ID)
+	assert.Equal(t, model.Failed, failed.ImageStatus)
+
+	_, err = os.Stat(orphanFile)
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(staging.Path(stagedFile))
+	assert.Nil(t, err)
+}
+
+func TestReconcilerSkipsUnreachableStaging(t *testing.T) {
+	truncateMissingPersons(testDB)
+	dir := filepath.Join(t.TempDir(), "staging")
+	staging, err := upload.NewStaging(dir)
+	assert.Nil(t, err)
+
+	row := model.MissingPersons{
+		Name:        "Joko",
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     uuid.NewString() + ".png",
+		ImageStatus: model.Pending,
+		CreatedAt:   time.Now().Add(-2 * time.Hour),
+	}
+	assert.Nil(t, testDB.Create(&row).Error)
+
+	// ===== volume staging tidak ter-mount =====
+	assert.Nil(t, os.Remove(dir))
+
+	err = worker.NewReconciler(testDB, staging).Reconcile(context.Background())
+	assert.NotNil(t, err)
+
+	// ===== row tidak ditandai failed =====
+	var unchanged model.MissingPersons
+	assert.Nil(t, testDB.First(&unchanged, "id = ?", row.ID).Error)
+	assert.Equal(t, model.Pending, unchanged.ImageStatus)
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/cmd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+func setWorkerConfigEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_PORT", "5432")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_PASSWORD", "habib123")
+	t.Setenv("DB_NAME", "missing_person_test")
+	t.Setenv("DB_SSLMODE", "disable")
+	t.Setenv("STORAGE_DRIVER", "local")
+	t.Setenv("LOCAL_STORAGE_DIR", t.TempDir())
+	t.Setenv("UPLOAD_STAGING_DIR", t.TempDir())
+	// worker tidak butuh JWT secret
+	t.Setenv("JWT_SECRET", "")
+}
+
+func TestParseRole(t *testing.T) {
+	for _, value := range []string{"api", "worker", "all"} {
+		role, err := wire.ParseRole(value)
+		assert.Nil(t, err)
+		assert.Equal(t, wire.Role(value), role)
+	}
+
+	_, err := wire.ParseRole("scheduler")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `unknown role "scheduler"`)
+
+	assert.True(t, wire.RoleAPI.ServesHTTP())
+	assert.False(t, wire.RoleAPI.RunsJobs())
+	assert.False(t, wire.RoleWorker.ServesHTTP())
+	assert.True(t, wire.RoleWorker.RunsJobs())
+	assert.True(t, wire.RoleAll.ServesHTTP())
+	assert.True(t, wire.RoleAll.RunsJobs())
+}
+
+func TestInitializeRoleWorker(t *testing.T) {
+	setWorkerConfigEnv(t)
+
+	app, err := wire.InitializeRole(wire.RoleWorker)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	// graph worker: tanpa router dan controller
+	assert.Nil(t, app.Router)
+	assert.NotNil(t, app.Worker)
+	assert.NotNil(t, app.QueueMetrics)
+
+	sqlDB, err := app.DB.DB()
+	assert.Nil(t, err)
+	assert.Nil(t, sqlDB.Close())
+}
+
+func TestInitializeRoleAPIRequiresAuthConfig(t *testing.T) {
+	setWorkerConfigEnv(t)
+
+	// api dan all tetap memvalidasi konfigurasi auth
+	for _, role := range []wire.Role{wire.RoleAPI, wire.RoleAll} {
+		_, err := wire.InitializeRole(role)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "JWT_SECRET is required")
+	}
+}
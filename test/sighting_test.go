@@ -0,0 +1,258 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func createReadyMissingPerson(t *testing.T) model.MissingPersons {
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Age:              63,
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      "ready",
+	}
+
+	err := testDB.Create(&missingPerson).Error
+	assert.Nil(t, err)
+
+	return missingPerson
+}
+
+func TestCreateSightingSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	missingPerson := createReadyMissingPerson(t)
+
+	// ===== multipart body =====
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("seen_at", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	_ = writer.WriteField("location", "Terminal Amplas")
+	_ = writer.WriteField("latitude", "3.5314")
+	_ = writer.WriteField("longitude", "98.7166")
+	_ = writer.WriteField("description", "duduk di ruang tunggu, pakai topi hitam")
+	_ = writer.WriteField("reporter_name", "Andi")
+	_ = writer.WriteField("reporter_contact", "08129999999")
+
+	fileWriter, _ := writer.CreateFormFile("photo", "sighting-image.jpg")
+	fileWriter.Write(testPNG(t, 100, 100))
+
+	writer.Close()
+
+	// ===== request =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/missing-persons/"+missingPerson.ID.String()+"/sightings",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	assert.Equal(t, "OK", response["status"])
+
+	data := response["data"].(map[string]any)
+
+	assert.Equal(t, missingPerson.ID.String(), data["missing_person_id"])
+	assert.Equal(t, "Terminal Amplas", data["location"])
+	assert.Equal(t, "pending", data["image_status"])
+	assert.Nil(t, data["photos"])
+
+	// ===== assert DB =====
+	var count int64
+	testDB.Model(&model.Sighting{}).Where("missing_person_id = ?", missingPerson.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	testDB.Model(&model.Job{}).Where("payload->>'source_table' = ?", model.SourceSightings).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestCreateSightingWithoutPhotoSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	missingPerson := createReadyMissingPerson(t)
+
+	// ===== multipart body =====
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("seen_at", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	_ = writer.WriteField("location", "Terminal Amplas")
+	_ = writer.WriteField("description", "duduk di ruang tunggu")
+	_ = writer.WriteField("reporter_contact", "08129999999")
+
+	writer.Close()
+
+	// ===== request =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/missing-persons/"+missingPerson.ID.String()+"/sightings",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].(map[string]any)
+
+	assert.Nil(t, data["photos"])
+	assert.Nil(t, data["image_status"])
+}
+
+func TestCreateSightingFailedIfMissingPersonNotFound(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	// ===== multipart body =====
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("seen_at", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	_ = writer.WriteField("location", "Terminal Amplas")
+	_ = writer.WriteField("description", "duduk di ruang tunggu")
+	_ = writer.WriteField("reporter_contact", "08129999999")
+
+	writer.Close()
+
+	// ===== request =====
+	req := httptest.NewRequest(
+		http.MethodPost,
+		"/api/v1/missing-persons/ef62bded-d467-4968-b686-742e256bd0b5/sightings",
+		body,
+	)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestListSightingSuccess(t *testing.T) {
+	truncateMissingPersons(testDB)
+	missingPerson := createReadyMissingPerson(t)
+
+	// ===== create data via GORM =====
+	older := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-48 * time.Hour),
+		Location:        "Pasar Petisah",
+		Description:     "berjalan ke arah utara",
+		ReporterContact: "08129999999",
+	}
+	newer := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-2 * time.Hour),
+		Location:        "Terminal Amplas",
+		Description:     "duduk di ruang tunggu",
+		ReporterContact: "08128888888",
+	}
+
+	assert.Nil(t, testDB.Create(&older).Error)
+	assert.Nil(t, testDB.Create(&newer).Error)
+
+	// ===== request GET =====
+	req := httptest.NewRequest(
+		http.MethodGet,
+		"/api/v1/missing-persons/"+missingPerson.ID.String()+"/sightings",
+		nil,
+	)
+
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	// ===== assert response =====
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]any
+	_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+	data := response["data"].([]any)
+	assert.Len(t, data, 2)
+
+	assert.Equal(t, newer.ID.String(), data[0].(map[string]any)["id"])
+	assert.Equal(t, older.ID.String(), data[1].(map[string]any)["id"])
+
+	// kontak saksi tidak untuk publik
+	for _, item := range data {
+		assert.NotContains(t, item.(map[string]any), "reporter_name")
+		assert.NotContains(t, item.(map[string]any), "reporter_contact")
+	}
+}
+
+func TestListSightingWitnessForOwnerAndModerator(t *testing.T) {
+	truncateMissingPersons(testDB)
+	owner, ownerToken := createUserWithToken(t, model.RoleReporter)
+	_, moderatorToken := createUserWithToken(t, model.RoleModerator)
+	_, otherToken := createUserWithToken(t, model.RoleReporter)
+
+	missingPerson := createReadyMissingPerson(t)
+	assert.Nil(t, testDB.Model(&missingPerson).Update("reporter_id", owner.ID).Error)
+
+	sighting := model.Sighting{
+		MissingPersonID: missingPerson.ID,
+		SeenAt:          time.Now().Add(-2 * time.Hour),
+		Location:        "Terminal Amplas",
+		Description:     "duduk di ruang tunggu",
+		ReporterName:    "Andi",
+		ReporterContact: "08128888888",
+	}
+	assert.Nil(t, testDB.Create(&sighting).Error)
+
+	list := func(token string) map[string]any {
+		req := httptest.NewRequest(
+			http.MethodGet,
+			"/api/v1/missing-persons/"+missingPerson.ID.String()+"/sightings",
+			nil,
+		)
+		req.Header.Set("Authorization", token)
+
+		recorder := httptest.NewRecorder()
+		testRouter.ServeHTTP(recorder, req)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+
+		var response map[string]any
+		_ = json.Unmarshal(recorder.Body.Bytes(), &response)
+
+		data := response["data"].([]any)
+		assert.Len(t, data, 1)
+		return data[0].(map[string]any)
+	}
+
+	// ===== pemilik laporan dan moderator =====
+	for _, token := range []string{ownerToken, moderatorToken} {
+		item := list(token)
+		assert.Equal(t, "Andi", item["reporter_name"])
+		assert.Equal(t, "08128888888", item["reporter_contact"])
+	}
+
+	// ===== user lain =====
+	item := list(otherToken)
+	assert.NotContains(t, item, "reporter_name")
+	assert.NotContains(t, item, "reporter_contact")
+}
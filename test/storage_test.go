@@ -0,0 +1,257 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mhbib34/missing-person-service/internal/config"
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/storage"
+	"github.com/Mhbib34/missing-person-service/internal/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorageUploadAndDelete(t *testing.T) {
+	dir := t.TempDir()
+
+	imageStorage, err := storage.NewLocalStorage(dir, "http://localhost:3000/images/")
+	assert.Nil(t, err)
+
+	// ===== source file =====
+	src := filepath.Join(t.TempDir(), "photo.jpg")
+	err = os.WriteFile(src, []byte("fake image"), 0644)
+	assert.Nil(t, err)
+
+	// ===== upload =====
+	err = imageStorage.Upload(context.Background(), "sightings/abc", src)
+	assert.Nil(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dir, "sightings", "abc"))
+	assert.Nil(t, err)
+	assert.Equal(t, "fake image", string(content))
+
+	assert.Equal(t, "http://localhost:3000/images/sightings/abc", imageStorage.URL("sightings/abc"))
+
+	// ===== delete, twice is fine =====
+	err = imageStorage.Delete(context.Background(), "sightings/abc")
+	assert.Nil(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, "sightings", "abc"))
+	assert.True(t, os.IsNotExist(err))
+
+	err = imageStorage.Delete(context.Background(), "sightings/abc")
+	assert.Nil(t, err)
+}
+
+func TestS3StorageCreatesBucketOnlyWhenEnabled(t *testing.T) {
+	// endpoint yang tidak bisa dihubungi, tanpa flag tidak ada request ke S3
+	cfg := config.S3Config{
+		Endpoint:  "127.0.0.1:1",
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		Bucket:    "missing-persons",
+	}
+
+	_, err := storage.NewS3Storage(cfg)
+	assert.Nil(t, err)
+
+	cfg.CreateBucket = true
+	_, err = storage.NewS3Storage(cfg)
+	assert.NotNil(t, err)
+}
+
+func TestImageDeleteJobWithLocalStorage(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	dir := t.TempDir()
+	imageStorage, err := storage.NewLocalStorage(dir, "http://localhost:3000/images")
+	assert.Nil(t, err)
+
+	missingPerson := model.MissingPersons{
+		Name:        "Joko",
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     "joko.png",
+		ImageStatus: model.Ready,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	// ===== rendition tersimpan di direktori <id> =====
+	src := filepath.Join(t.TempDir(), "photo.jpg")
+	assert.Nil(t, os.WriteFile(src, []byte("fake image"), 0644))
+	for _, name := range []string{"thumbnail", "card", "full"} {
+		assert.Nil(t, imageStorage.Upload(context.Background(), missingPerson.ID.String()+"/"+name, src))
+	}
+
+	// ===== delete job =====
+	job := model.NewImageJob(model.JobImageDelete, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	assert.Nil(t, testDB.Create(&job).Error)
+
+	queue := worker.NewQueue(testDB)
+	worker.RegisterImageJobs(queue, testDB, imageStorage, testStaging, 1)
+	runQueue(t, queue, job)
+
+	assert.Nil(t, testDB.First(&job, "id = ?", job.ID).Error)
+	assert.Equal(t, model.JobDone, job.Status)
+
+	_, err = os.Stat(filepath.Join(dir, missingPerson.ID.String()))
+	assert.True(t, os.IsNotExist(err))
+
+	var row model.MissingPersons
+	assert.Nil(t, testDB.First(&row, "id = ?", missingPerson.ID).Error)
+	assert.Equal(t, model.Deleted, row.ImageStatus)
+}
+
+// exifJPEG encodes a w x h photo, red on the left half and blue on the right,
+// with an EXIF segment holding the given orientation and a GPS latitude
+func exifJPEG(t *testing.T, w int, h int, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= w/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	var encoded bytes.Buffer
+	assert.Nil(t, jpeg.Encode(&encoded, img, &jpeg.Options{Quality: 95}))
+
+	// TIFF little endian: IFD0 dengan Orientation dan pointer ke GPS IFD,
+	// GPS IFD dengan GPSLatitudeRef "N"
+	le := binary.LittleEndian
+	tiff := []byte("II*\x00")
+	tiff = le.AppendUint32(tiff, 8)
+
+	tiff = le.AppendUint16(tiff, 2)
+	tiff = le.AppendUint16(tiff, 0x0112)
+	tiff = le.AppendUint16(tiff, 3)
+	tiff = le.AppendUint32(tiff, 1)
+	tiff = le.AppendUint16(tiff, orientation)
+	tiff = le.AppendUint16(tiff, 0)
+	tiff = le.AppendUint16(tiff, 0x8825)
+	tiff = le.AppendUint16(tiff, 4)
+	tiff = le.AppendUint32(tiff, 1)
+	tiff = le.AppendUint32(tiff, 8+2+2*12+4)
+	tiff = le.AppendUint32(tiff, 0)
+
+	tiff = le.AppendUint16(tiff, 1)
+	tiff = le.AppendUint16(tiff, 0x0001)
+	tiff = le.AppendUint16(tiff, 2)
+	tiff = le.AppendUint32(tiff, 2)
+	tiff = append(tiff, 'N', 0, 0, 0)
+	tiff = le.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	data := encoded.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+// jpegMarkers lists the markers of a JPEG file up to the image data
+func jpegMarkers(t *testing.T, data []byte) []byte {
+	var markers []byte
+	pos := 2
+	for pos+4 <= len(data) {
+		if !assert.Equal(t, byte(0xFF), data[pos]) {
+			return markers
+		}
+		marker := data[pos+1]
+		markers = append(markers, marker)
+		if marker == 0xDA {
+			return markers
+		}
+		pos += 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+	}
+	return markers
+}
+
+func TestImageProcessJobWithLocalStorage(t *testing.T) {
+	truncateMissingPersons(testDB)
+
+	dir := t.TempDir()
+	imageStorage, err := storage.NewLocalStorage(dir, "http://localhost:3000/images")
+	assert.Nil(t, err)
+
+	// ===== foto 800x400 dengan EXIF GPS, orientasi 6 (putar 90 derajat) =====
+	original := exifJPEG(t, 800, 400, 6)
+	assert.Contains(t, jpegMarkers(t, original), byte(0xE1))
+
+	missingPerson := model.MissingPersons{
+		Name:        "Joko",
+		Description: "celana pendek",
+		LastSeen:    "Medan",
+		Contact:     "08123456789",
+		PhotoID:     "joko-exif.jpg",
+		ImageStatus: model.Pending,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+	assert.Nil(t, os.WriteFile(testStaging.Path(missingPerson.PhotoID), original, 0644))
+
+	// ===== process job =====
+	job := model.NewImageJob(model.JobImageProcess, model.SourceMissingPersons, missingPerson.ID, missingPerson.PhotoID)
+	assert.Nil(t, testDB.Create(&job).Error)
+
+	queue := worker.NewQueue(testDB)
+	worker.RegisterImageJobs(queue, testDB, imageStorage, testStaging, 1)
+	runQueue(t, queue, job)
+
+	assert.Nil(t, testDB.First(&job, "id = ?", job.ID).Error)
+	assert.Equal(t, model.JobDone, job.Status)
+
+	var row model.MissingPersons
+	assert.Nil(t, testDB.First(&row, "id = ?", missingPerson.ID).Error)
+	assert.Equal(t, model.Ready, row.ImageStatus)
+	assert.Equal(t, "http://localhost:3000/images/"+missingPerson.ID.String()+"/full", row.Photos.Full)
+
+	// ===== ukuran rendition setelah diputar jadi 400x800 =====
+	sizes := map[string]image.Point{
+		"thumbnail": {128, 128},
+		"card":      {400, 400},
+		"full":      {400, 800},
+	}
+	for name, size := range sizes {
+		data, err := os.ReadFile(filepath.Join(dir, missingPerson.ID.String(), name))
+		if !assert.Nil(t, err, name) {
+			continue
+		}
+
+		// metadata EXIF (termasuk GPS) tidak ikut
+		assert.NotContains(t, jpegMarkers(t, data), byte(0xE1), name)
+		assert.False(t, bytes.Contains(data, []byte("Exif")), name)
+
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		assert.Nil(t, err, name)
+		assert.Equal(t, size, img.Bounds().Size(), name)
+	}
+
+	// ===== pixel ikut diputar: kiri (merah) jadi atas, kanan (biru) jadi bawah =====
+	data, err := os.ReadFile(filepath.Join(dir, missingPerson.ID.String(), "full"))
+	assert.Nil(t, err)
+	full, err := jpeg.Decode(bytes.NewReader(data))
+	assert.Nil(t, err)
+
+	top := color.RGBAModel.Convert(full.At(200, 100)).(color.RGBA)
+	bottom := color.RGBAModel.Convert(full.At(200, 700)).(color.RGBA)
+	assert.Greater(t, top.R, uint8(200))
+	assert.Less(t, top.B, uint8(60))
+	assert.Greater(t, bottom.B, uint8(200))
+	assert.Less(t, bottom.R, uint8(60))
+
+	// file staging dibersihkan
+	_, err = os.Stat(testStaging.Path(missingPerson.PhotoID))
+	assert.True(t, os.IsNotExist(err))
+}
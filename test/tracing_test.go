@@ -0,0 +1,131 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mhbib34/missing-person-service/internal/model"
+	"github.com/Mhbib34/missing-person-service/internal/tracing"
+	"github.com/Mhbib34/missing-person-service/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	spanRecorderOnce sync.Once
+	spanRecorder     *tracetest.SpanRecorder
+)
+
+// recordSpans installs an in-memory tracer provider, the global provider can
+// only be set once so every test shares it
+func recordSpans() *tracetest.SpanRecorder {
+	spanRecorderOnce.Do(func() {
+		spanRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+	})
+	return spanRecorder
+}
+
+// endedSpans returns the ended spans of one trace by name
+func endedSpans(recorder *tracetest.SpanRecorder, traceID trace.TraceID) map[string]sdktrace.ReadOnlySpan {
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == traceID {
+			spans[span.Name()] = span
+		}
+	}
+	return spans
+}
+
+func TestTracingStoresTraceContextInJob(t *testing.T) {
+	recorded := recordSpans()
+	truncateMissingPersons(testDB)
+	reporter, token := createUserWithToken(t, model.RoleReporter)
+
+	missingPerson := model.MissingPersons{
+		Name:             "Joko",
+		Description:      "celana pendek",
+		LastSeen:         "Medan",
+		Contact:          "08123456789",
+		PhotoID:          "test-image.jpg",
+		ModerationStatus: model.Approved,
+		ImageStatus:      model.Ready,
+		ReporterID:       &reporter.ID,
+	}
+	assert.Nil(t, testDB.Create(&missingPerson).Error)
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(
+		http.MethodDelete,
+		"/api/v1/missing-persons/"+missingPerson.ID.String(),
+		strings.NewReader(`{"reason":"Sudah ditemukan keluarga"}`),
+	)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", token)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	recorder := httptest.NewRecorder()
+	testRouter.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	// ===== request, usecase dan query masuk ke trace yang sama =====
+	id, err := trace.TraceIDFromHex(traceID)
+	assert.Nil(t, err)
+
+	spans := endedSpans(recorded, id)
+	assert.Contains(t, spans, "DELETE /api/v1/missing-persons/:id")
+	assert.Contains(t, spans, "MissingPersonUsecase.Delete")
+	assert.Contains(t, spans, "gorm.create")
+
+	// ===== job membawa trace context =====
+	var job model.Job
+	err = testDB.Where("ordering_key = ? AND kind = ?", model.ImageJobKey(model.SourceMissingPersons, missingPerson.ID), model.JobImageDelete).
+		First(&job).Error
+	assert.Nil(t, err)
+	assert.Contains(t, job.TraceContext["traceparent"], traceID)
+}
+
+func TestTracingJobContinuesTrace(t *testing.T) {
+	recorded := recordSpans()
+	truncateMissingPersons(testDB)
+
+	// ===== job dibuat di dalam span =====
+	ctx, parent := tracing.Start(context.Background(), "enqueue")
+	job := model.Job{
+		Kind:         "test.trace",
+		Payload:      []byte(`{"n":1}`),
+		Status:       model.JobPending,
+		RunAt:        time.Now().Add(-time.Second),
+		TraceContext: tracing.Inject(ctx),
+	}
+	assert.Nil(t, testDB.Create(&job).Error)
+	parent.End()
+
+	var handlerTraceID trace.TraceID
+	queue := worker.NewQueue(testDB)
+	worker.Register(queue, "test.trace", worker.Options{}, func(ctx context.Context, job model.Job, payload testPayload) error {
+		handlerTraceID = trace.SpanContextFromContext(ctx).TraceID()
+		return nil
+	})
+
+	runQueue(t, queue, job)
+
+	// ===== span job adalah child dari span yang membuatnya =====
+	assert.Equal(t, parent.SpanContext().TraceID(), handlerTraceID)
+
+	spans := endedSpans(recorded, parent.SpanContext().TraceID())
+	assert.Contains(t, spans, "job test.trace")
+	if span, ok := spans["job test.trace"]; ok {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+}